@@ -36,6 +36,12 @@ func main() {
 	var kubeContext string
 	var kubeConfig string
 	var outputPrefix string
+	var fromManifests string
+	var specFile string
+	var output string
+	var junitReport string
+	var diagnosticsPath string
+	var diagnosticsLogLines int
 
 	makeTrackerOptions := func(mode string) tracker.Options {
 		// rollout track defaults
@@ -123,41 +129,98 @@ func main() {
 	rootCmd.AddCommand(versionCmd)
 
 	multitrackCmd := &cobra.Command{
-		Use:     "multitrack",
-		Short:   "Track multiple resources using multitrack tracker",
-		Example: `echo '{"Deployments":[{"ResourceName":"mydeploy","Namespace":"myns"},{"ResourceName":"myresource","Namespace":"myns","FailMode":"HopeUntilEndOfDeployProcess","AllowFailuresCount":3,"SkipLogsForContainers":["two", "three"]}], "StatefulSets":[{"ResourceName":"mysts","Namespace":"myns"}]}' | kubedog multitrack`,
+		Use:   "multitrack",
+		Short: "Track multiple resources using multitrack tracker",
+		Example: `echo '{"Deployments":[{"ResourceName":"mydeploy","Namespace":"myns"},{"ResourceName":"myresource","Namespace":"myns","FailMode":"HopeUntilEndOfDeployProcess","AllowFailuresCount":3,"SkipLogsForContainers":["two", "three"]}], "StatefulSets":[{"ResourceName":"mysts","Namespace":"myns"}]}' | kubedog multitrack
+kubedog multitrack --spec-file specs.yaml
+kubectl apply -f dir && kubedog multitrack --from-manifests dir`,
 		Run: func(cmd *cobra.Command, args []string) {
+			if specFile != "" && fromManifests != "" {
+				fmt.Fprintf(os.Stderr, "Only one of --spec-file and --from-manifests options could be specified\n")
+				os.Exit(1)
+			}
+
 			init()
 
 			if outputPrefix != "" {
 				logboek.SetPrefix(outputPrefix, nil)
 			}
 
-			specsInput, err := ioutil.ReadAll(os.Stdin)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error reading stdin: %s\n", err)
-				os.Exit(1)
-			}
-
 			specs := multitrack.MultitrackSpecs{}
-			err = json.Unmarshal(specsInput, &specs)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error parsing MultitrackSpecs json: %s\n", err)
-				os.Exit(1)
+			if specFile != "" {
+				var specsInput []byte
+				var err error
+				if specFile == "-" {
+					specsInput, err = ioutil.ReadAll(os.Stdin)
+				} else {
+					specsInput, err = ioutil.ReadFile(specFile)
+				}
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error reading spec file: %s\n", err)
+					os.Exit(1)
+				}
+
+				specs, err = multitrack.ParseSpecsFile(specsInput)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error parsing spec file %s: %s\n", specFile, err)
+					os.Exit(1)
+				}
+			} else if fromManifests != "" {
+				var err error
+				specs, err = multitrack.ReadManifestsSpecs(fromManifests, namespace)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error reading manifests: %s\n", err)
+					os.Exit(1)
+				}
+			} else {
+				specsInput, err := ioutil.ReadAll(os.Stdin)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error reading stdin: %s\n", err)
+					os.Exit(1)
+				}
+
+				err = json.Unmarshal(specsInput, &specs)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error parsing MultitrackSpecs json: %s\n", err)
+					os.Exit(1)
+				}
 			}
 
 			multitrackOptions := multitrack.MultitrackOptions{
 				StatusProgressPeriod: time.Second * time.Duration(statusProgressPeriodSeconds),
 				Options:              makeTrackerOptions("track"),
+				DynamicClient:        kube.DynamicClient,
+				JUnitReportFile:      junitReport,
+				DiagnosticsPath:      diagnosticsPath,
+				DiagnosticsLogLines:  diagnosticsLogLines,
 			}
-			err = multitrack.Multitrack(kube.Kubernetes, specs, multitrackOptions)
+
+			switch output {
+			case "text":
+			case "json":
+				// Only events are printed to stdout, one json object per line
+				logboek.MuteOut()
+				logboek.MuteErr()
+				multitrackOptions.EventSink = multitrack.NewJSONEventSink(os.Stdout)
+			default:
+				fmt.Fprintf(os.Stderr, "Bad --output value %q: text or json expected\n", output)
+				os.Exit(1)
+			}
+
+			err := multitrack.Multitrack(kube.Kubernetes, specs, multitrackOptions)
 			if err != nil {
 				fmt.Fprintln(os.Stderr, err)
 				os.Exit(1)
 			}
 		},
 	}
+	multitrackCmd.PersistentFlags().StringVarP(&specFile, "spec-file", "f", "", "Path to MultitrackSpecs file in yaml or json format ('-' to read from stdin) instead of MultitrackSpecs json from stdin. Regular expressions are strings, durations are seconds or strings like 90s.")
+	multitrackCmd.PersistentFlags().StringVarP(&fromManifests, "from-manifests", "", "", "Track resources from the manifests file or directory instead of MultitrackSpecs json from stdin. Options of the resources are read from kubedog.io/* annotations.")
 	multitrackCmd.PersistentFlags().Int64VarP(&statusProgressPeriodSeconds, "status-progress-period", "", 5, "Status progress period in seconds. Set -1 to stop showing status progress.")
+	multitrackCmd.PersistentFlags().StringVarP(&junitReport, "junit-report", "", "", "Path of the JUnit XML report file with a test case for each tracked resource, which is written when tracking is done.")
+	multitrackCmd.PersistentFlags().StringVarP(&diagnosticsPath, "diagnostics", "", "", "Directory (or .tar.gz file) to save diagnostics of the failed resources to when tracking is failed: objects, events, pods and last logs of the containers.")
+	multitrackCmd.PersistentFlags().IntVarP(&diagnosticsLogLines, "diagnostics-log-lines", "", 100, "Number of the last log lines of each container to save into diagnostics.")
+	multitrackCmd.PersistentFlags().StringVarP(&output, "output", "o", "text", "Output format: text or json. In json mode each event (resource added, ready, failed, k8s event, pod added, container error, log line, status) is printed as a json object on a separate line.")
 
 	rootCmd.AddCommand(multitrackCmd)
 
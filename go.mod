@@ -9,6 +9,7 @@ require (
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/spf13/cobra v0.0.3
 	golang.org/x/crypto v0.0.0-20190820162420-60c769a6c586
+	gopkg.in/yaml.v2 v2.2.8
 	k8s.io/api v0.16.7
 	k8s.io/apimachinery v0.16.8-beta.0
 	k8s.io/client-go v0.16.7
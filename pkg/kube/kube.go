@@ -3,7 +3,9 @@ package kube
 import (
 	"fmt"
 	"io/ioutil"
+	"strings"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
@@ -234,9 +236,26 @@ func getInClusterContextClient() (clientset kubernetes.Interface, err error) {
 }
 
 func GroupVersionResourceByKind(kind string) (schema.GroupVersionResource, error) {
-	lists, err := Kubernetes.Discovery().ServerPreferredResources()
-	if err != nil {
-		return schema.GroupVersionResource{}, err
+	return GroupVersionResourceByAPIVersionAndKind(Kubernetes, "", kind)
+}
+
+// GroupVersionResourceByAPIVersionAndKind finds resource of the kind using discovery,
+// the preferred version of the kind is used when apiVersion is empty
+func GroupVersionResourceByAPIVersionAndKind(kube kubernetes.Interface, apiVersion, kind string) (schema.GroupVersionResource, error) {
+	var lists []*metav1.APIResourceList
+
+	if apiVersion != "" {
+		list, err := kube.Discovery().ServerResourcesForGroupVersion(apiVersion)
+		if err != nil {
+			return schema.GroupVersionResource{}, err
+		}
+		lists = append(lists, list)
+	} else {
+		var err error
+		lists, err = kube.Discovery().ServerPreferredResources()
+		if err != nil {
+			return schema.GroupVersionResource{}, err
+		}
 	}
 
 	for _, list := range lists {
@@ -254,6 +273,11 @@ func GroupVersionResourceByKind(kind string) (schema.GroupVersionResource, error
 				continue
 			}
 
+			// skip subresources such as deployments/scale
+			if strings.Contains(resource.Name, "/") {
+				continue
+			}
+
 			if kind == resource.Kind {
 				groupVersionResource := schema.GroupVersionResource{
 					Resource: resource.Name,
@@ -266,5 +290,8 @@ func GroupVersionResourceByKind(kind string) (schema.GroupVersionResource, error
 		}
 	}
 
+	if apiVersion != "" {
+		return schema.GroupVersionResource{}, fmt.Errorf("kind %s is not supported by %s", kind, apiVersion)
+	}
 	return schema.GroupVersionResource{}, fmt.Errorf("kind %s is not supported", kind)
 }
@@ -0,0 +1,212 @@
+package argorollout
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/controller"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	watchtools "k8s.io/client-go/tools/watch"
+)
+
+type Feed interface {
+	controller.ControllerFeed
+
+	OnStatus(func(RolloutStatus) error)
+
+	GetStatus() RolloutStatus
+	Track(name, namespace string, kube kubernetes.Interface, dynamicClient dynamic.Interface, opts tracker.Options) error
+}
+
+func NewFeed() Feed {
+	return &feed{}
+}
+
+type feed struct {
+	controller.CommonControllerFeed
+
+	OnStatusFunc func(RolloutStatus) error
+
+	statusMux sync.Mutex
+	status    RolloutStatus
+}
+
+func (f *feed) OnStatus(function func(RolloutStatus) error) {
+	f.OnStatusFunc = function
+}
+
+func (f *feed) Track(name, namespace string, kube kubernetes.Interface, dynamicClient dynamic.Interface, opts tracker.Options) error {
+	errorChan := make(chan error, 0)
+	doneChan := make(chan bool, 0)
+
+	parentContext := opts.ParentContext
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(parentContext, opts.Timeout)
+	defer cancel()
+
+	rolloutTracker := NewTracker(ctx, name, namespace, kube, dynamicClient, opts)
+
+	go func() {
+		if debug.Debug() {
+			fmt.Printf("  goroutine: start rollout/%s tracker\n", name)
+		}
+		err := rolloutTracker.Track()
+		if err != nil {
+			errorChan <- err
+		} else {
+			doneChan <- true
+		}
+	}()
+
+	if debug.Debug() {
+		fmt.Printf("  rollout/%s: for-select RolloutTracker channels\n", name)
+	}
+
+	for {
+		select {
+		case status := <-rolloutTracker.Added:
+			f.setStatus(status)
+
+			if f.OnAddedFunc != nil {
+				err := f.OnAddedFunc(status.IsReady)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case status := <-rolloutTracker.Ready:
+			f.setStatus(status)
+
+			if f.OnReadyFunc != nil {
+				err := f.OnReadyFunc()
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case status := <-rolloutTracker.Failed:
+			f.setStatus(status)
+
+			if f.OnFailedFunc != nil {
+				err := f.OnFailedFunc(status.FailedReason)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case msg := <-rolloutTracker.EventMsg:
+			if f.OnEventMsgFunc != nil {
+				err := f.OnEventMsgFunc(msg)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case report := <-rolloutTracker.AddedReplicaSet:
+			f.setStatus(report.RolloutStatus)
+
+			if f.OnAddedReplicaSetFunc != nil {
+				err := f.OnAddedReplicaSetFunc(report.ReplicaSet)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case report := <-rolloutTracker.AddedPod:
+			f.setStatus(report.RolloutStatus)
+
+			if f.OnAddedPodFunc != nil {
+				err := f.OnAddedPodFunc(report.ReplicaSetPod)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case chunk := <-rolloutTracker.PodLogChunk:
+			if debug.Debug() {
+				fmt.Printf("    rollout/%s pod `%s` log chunk\n", rolloutTracker.ResourceName, chunk.PodName)
+				for _, line := range chunk.LogLines {
+					fmt.Printf("po/%s [%s] %s\n", chunk.PodName, line.Timestamp, line.Message)
+				}
+			}
+
+			if f.OnPodLogChunkFunc != nil {
+				err := f.OnPodLogChunkFunc(chunk)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case report := <-rolloutTracker.PodError:
+			f.setStatus(report.RolloutStatus)
+
+			if f.OnPodErrorFunc != nil {
+				err := f.OnPodErrorFunc(report.ReplicaSetPodError)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case status := <-rolloutTracker.Status:
+			f.setStatus(status)
+
+			if f.OnStatusFunc != nil {
+				err := f.OnStatusFunc(status)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case err := <-errorChan:
+			return err
+		case <-doneChan:
+			return nil
+		}
+	}
+}
+
+func (f *feed) setStatus(status RolloutStatus) {
+	f.statusMux.Lock()
+	defer f.statusMux.Unlock()
+	f.status = status
+}
+
+func (f *feed) GetStatus() RolloutStatus {
+	f.statusMux.Lock()
+	defer f.statusMux.Unlock()
+	return f.status
+}
@@ -0,0 +1,322 @@
+package argorollout
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/flant/kubedog/pkg/tracker/indicators"
+	"github.com/flant/kubedog/pkg/tracker/pod"
+)
+
+var (
+	RolloutsResource     = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"}
+	AnalysisRunsResource = schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "analysisruns"}
+)
+
+const (
+	// PodTemplateHashLabel is set by the Argo Rollouts controller on ReplicaSets, Pods and AnalysisRuns of the Rollout
+	PodTemplateHashLabel = "rollouts-pod-template-hash"
+
+	CanaryStrategy    = "Canary"
+	BlueGreenStrategy = "BlueGreen"
+
+	HealthyPhase     = "Healthy"
+	ProgressingPhase = "Progressing"
+	PausedPhase      = "Paused"
+	DegradedPhase    = "Degraded"
+	// AbortedPhase is not reported by the controller as is: aborted Rollout has status.abort set
+	AbortedPhase = "Aborted"
+)
+
+type AnalysisRunStatus struct {
+	Name    string
+	Phase   string
+	Message string
+	// PodTemplateHash of the Rollout revision which is analyzed
+	PodTemplateHash string
+}
+
+func (s AnalysisRunStatus) IsFinished() bool {
+	switch s.Phase {
+	case "Successful", "Failed", "Error", "Inconclusive":
+		return true
+	}
+	return false
+}
+
+func (s AnalysisRunStatus) IsFailed() bool {
+	return s.Phase == "Failed" || s.Phase == "Error"
+}
+
+type RolloutStatus struct {
+	StatusGeneration uint64
+
+	Strategy string
+	Phase    string
+	Message  string
+
+	ReplicasIndicator  *indicators.Int32EqualConditionIndicator
+	UpToDateIndicator  *indicators.Int32EqualConditionIndicator
+	AvailableIndicator *indicators.Int32EqualConditionIndicator
+
+	// StepsCount is the number of canary steps, CurrentStepIndex equals StepsCount when all steps are done
+	StepsCount       int
+	CurrentStepIndex int
+	CurrentStep      string
+	// CanaryWeight is the traffic weight of the canary in percents, -1 when unknown
+	CanaryWeight int64
+
+	IsPaused     bool
+	PauseReasons []string
+
+	// Pod template hashes of the stable revision and of the revision being rolled out
+	StableHash  string
+	CurrentHash string
+	// ReplicaSets of the stable and of the canary (preview for the BlueGreen strategy) revisions
+	StableReplicaSetName string
+	CanaryReplicaSetName string
+
+	// AnalysisRuns of the revision being rolled out, map by AnalysisRun name
+	AnalysisRuns map[string]AnalysisRunStatus
+
+	WaitingForMessages []string
+
+	IsReady      bool
+	IsFailed     bool
+	FailedReason string
+
+	Pods map[string]pod.PodStatus
+	// New Pod belongs to the ReplicaSet of the revision being rolled out
+	NewPodsNames []string
+}
+
+func NewRolloutStatus(object *unstructured.Unstructured, statusGeneration uint64, isTrackerFailed bool, trackerFailedReason string, podsStatuses map[string]pod.PodStatus, newPodsNames []string, analysisRuns map[string]AnalysisRunStatus) RolloutStatus {
+	res := RolloutStatus{
+		StatusGeneration: statusGeneration,
+		CanaryWeight:     -1,
+		AnalysisRuns:     make(map[string]AnalysisRunStatus),
+		Pods:             make(map[string]pod.PodStatus),
+		NewPodsNames:     newPodsNames,
+	}
+
+	res.StableHash, _, _ = unstructured.NestedString(object.Object, "status", "stableRS")
+	res.CurrentHash, _, _ = unstructured.NestedString(object.Object, "status", "currentPodHash")
+	if res.StableHash != "" {
+		res.StableReplicaSetName = fmt.Sprintf("%s-%s", object.GetName(), res.StableHash)
+	}
+	if res.CurrentHash != "" && res.CurrentHash != res.StableHash {
+		res.CanaryReplicaSetName = fmt.Sprintf("%s-%s", object.GetName(), res.CurrentHash)
+	}
+
+processingPodsStatuses:
+	for k, v := range podsStatuses {
+		res.Pods[k] = v
+
+		for _, newPodName := range newPodsNames {
+			if newPodName == k {
+				if v.StatusIndicator != nil {
+					// New Pod should be Running
+					v.StatusIndicator.TargetValue = "Running"
+				}
+				continue processingPodsStatuses
+			}
+		}
+
+		if v.StatusIndicator != nil && res.CanaryReplicaSetName != "" {
+			// Pods of the stable revision are gone when the canary is promoted
+			v.StatusIndicator.TargetValue = ""
+		}
+	}
+
+	for name, run := range analysisRuns {
+		if run.PodTemplateHash == "" || run.PodTemplateHash == res.CurrentHash {
+			res.AnalysisRuns[name] = run
+		}
+	}
+
+	if _, found, _ := unstructured.NestedMap(object.Object, "spec", "strategy", "blueGreen"); found {
+		res.Strategy = BlueGreenStrategy
+	} else {
+		res.Strategy = CanaryStrategy
+		setCanaryStepsStatus(&res, object)
+	}
+
+	res.Phase, _, _ = unstructured.NestedString(object.Object, "status", "phase")
+	res.Message, _, _ = unstructured.NestedString(object.Object, "status", "message")
+	if isAborted, _, _ := unstructured.NestedBool(object.Object, "status", "abort"); isAborted {
+		res.Phase = AbortedPhase
+	}
+
+	setPauseStatus(&res, object)
+
+	observedGeneration, _, _ := unstructured.NestedString(object.Object, "status", "observedGeneration")
+	isGenerationObserved := true
+	if generation, err := strconv.ParseInt(observedGeneration, 10, 64); err == nil && generation < object.GetGeneration() {
+		// Older controllers store hash of the spec in the observedGeneration, which is not comparable
+		isGenerationObserved = false
+	}
+
+	replicas, hasReplicas, _ := unstructured.NestedInt64(object.Object, "spec", "replicas")
+	if !hasReplicas {
+		replicas = 1
+	}
+	statusReplicas, _, _ := unstructured.NestedInt64(object.Object, "status", "replicas")
+	updatedReplicas, _, _ := unstructured.NestedInt64(object.Object, "status", "updatedReplicas")
+	availableReplicas, _, _ := unstructured.NestedInt64(object.Object, "status", "availableReplicas")
+
+	res.ReplicasIndicator = &indicators.Int32EqualConditionIndicator{Value: int32(statusReplicas), TargetValue: int32(replicas)}
+	res.UpToDateIndicator = &indicators.Int32EqualConditionIndicator{Value: int32(updatedReplicas), TargetValue: int32(replicas)}
+	res.AvailableIndicator = &indicators.Int32EqualConditionIndicator{Value: int32(availableReplicas), TargetValue: int32(replicas)}
+
+	switch {
+	case res.Phase == DegradedPhase || res.Phase == AbortedPhase:
+		res.IsFailed = true
+		res.FailedReason = fmt.Sprintf("rollout is %s", strings.ToLower(res.Phase))
+		if res.Message != "" {
+			res.FailedReason += fmt.Sprintf(": %s", res.Message)
+		}
+
+	case !isGenerationObserved:
+		res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("observed generation %s should be >= %d", observedGeneration, object.GetGeneration()))
+
+	case res.Phase == HealthyPhase:
+		res.IsReady = true
+
+	case res.Phase == "":
+		// Older controllers do not report the phase
+		res.IsReady = res.StableHash != "" && res.StableHash == res.CurrentHash && updatedReplicas == replicas && availableReplicas >= replicas
+	}
+
+	if !res.IsReady && !res.IsFailed {
+		if updatedReplicas != replicas {
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("up-to-date %d->%d", updatedReplicas, replicas))
+		}
+		if availableReplicas != replicas {
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("available %d->%d", availableReplicas, replicas))
+		}
+		if res.StepsCount > 0 && res.CurrentStepIndex < res.StepsCount {
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("step %d/%d %s", res.CurrentStepIndex+1, res.StepsCount, res.CurrentStep))
+		}
+		if res.IsPaused {
+			if len(res.PauseReasons) > 0 {
+				res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("promotion (paused: %s)", strings.Join(res.PauseReasons, ", ")))
+			} else {
+				res.WaitingForMessages = append(res.WaitingForMessages, "promotion (paused)")
+			}
+		}
+
+		res.IsFailed = isTrackerFailed
+		res.FailedReason = trackerFailedReason
+	}
+
+	return res
+}
+
+func setCanaryStepsStatus(res *RolloutStatus, object *unstructured.Unstructured) {
+	steps, _, _ := unstructured.NestedSlice(object.Object, "spec", "strategy", "canary", "steps")
+	res.StepsCount = len(steps)
+
+	currentStepIndex, found, _ := unstructured.NestedInt64(object.Object, "status", "currentStepIndex")
+	if !found {
+		currentStepIndex = int64(len(steps))
+	}
+	res.CurrentStepIndex = int(currentStepIndex)
+
+	if res.CurrentStepIndex < len(steps) {
+		if step, ok := steps[res.CurrentStepIndex].(map[string]interface{}); ok {
+			res.CurrentStep = formatCanaryStep(step)
+		}
+	}
+
+	if weight, found, _ := unstructured.NestedInt64(object.Object, "status", "canary", "weights", "canary", "weight"); found {
+		res.CanaryWeight = weight
+		return
+	}
+
+	// Older controllers do not report weights: the weight is set by the last passed setWeight step
+	if res.CurrentStepIndex >= len(steps) {
+		res.CanaryWeight = 100
+		return
+	}
+	res.CanaryWeight = 0
+	for i := res.CurrentStepIndex; i >= 0; i-- {
+		step, ok := steps[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if weight, found, _ := unstructured.NestedInt64(step, "setWeight"); found {
+			res.CanaryWeight = weight
+			return
+		}
+	}
+}
+
+func formatCanaryStep(step map[string]interface{}) string {
+	if weight, found, _ := unstructured.NestedInt64(step, "setWeight"); found {
+		return fmt.Sprintf("setWeight %d", weight)
+	}
+
+	if pause, found, _ := unstructured.NestedMap(step, "pause"); found {
+		if duration, hasDuration := pause["duration"]; hasDuration {
+			return fmt.Sprintf("pause %v", duration)
+		}
+		return "pause"
+	}
+
+	var keys []string
+	for key := range step {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return strings.Join(keys, ",")
+}
+
+func setPauseStatus(res *RolloutStatus, object *unstructured.Unstructured) {
+	if isPaused, _, _ := unstructured.NestedBool(object.Object, "spec", "paused"); isPaused {
+		res.IsPaused = true
+		res.PauseReasons = append(res.PauseReasons, "spec.paused")
+	}
+
+	if isPaused, _, _ := unstructured.NestedBool(object.Object, "status", "controllerPause"); isPaused {
+		res.IsPaused = true
+	}
+
+	pauseConditions, _, _ := unstructured.NestedSlice(object.Object, "status", "pauseConditions")
+	for _, c := range pauseConditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		res.IsPaused = true
+		if reason, _, _ := unstructured.NestedString(condition, "reason"); reason != "" {
+			res.PauseReasons = append(res.PauseReasons, reason)
+		}
+	}
+
+	if res.Phase == PausedPhase {
+		res.IsPaused = true
+	}
+}
+
+func newAnalysisRunStatus(object *unstructured.Unstructured) AnalysisRunStatus {
+	res := AnalysisRunStatus{
+		Name:            object.GetName(),
+		PodTemplateHash: object.GetLabels()[PodTemplateHashLabel],
+	}
+
+	res.Phase, _, _ = unstructured.NestedString(object.Object, "status", "phase")
+	res.Message, _, _ = unstructured.NestedString(object.Object, "status", "message")
+
+	if res.Phase == "" {
+		res.Phase = "Pending"
+	}
+
+	return res
+}
@@ -0,0 +1,618 @@
+package argorollout
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+	"github.com/flant/kubedog/pkg/tracker/event"
+	"github.com/flant/kubedog/pkg/tracker/pod"
+	"github.com/flant/kubedog/pkg/tracker/replicaset"
+	"github.com/flant/kubedog/pkg/utils"
+)
+
+type ReplicaSetAddedReport struct {
+	ReplicaSet    replicaset.ReplicaSet
+	RolloutStatus RolloutStatus
+}
+
+type PodAddedReport struct {
+	ReplicaSetPod replicaset.ReplicaSetPod
+	RolloutStatus RolloutStatus
+}
+
+type PodErrorReport struct {
+	ReplicaSetPodError replicaset.ReplicaSetPodError
+	RolloutStatus      RolloutStatus
+}
+
+// Tracker watches the Argo Rollout along with its ReplicaSets, Pods and AnalysisRuns.
+// ReplicaSet of the revision being rolled out is reported as new.
+type Tracker struct {
+	tracker.Tracker
+
+	Dynamic dynamic.Interface
+
+	State tracker.TrackerState
+
+	knownReplicaSets map[string]*appsv1.ReplicaSet
+	lastObject       *unstructured.Unstructured
+	failedReason     string
+	podStatuses      map[string]pod.PodStatus
+	rsNameByPod      map[string]string
+	analysisRuns     map[string]AnalysisRunStatus
+
+	TrackedPodsNames []string
+
+	Added  chan RolloutStatus
+	Ready  chan RolloutStatus
+	Failed chan RolloutStatus
+	Status chan RolloutStatus
+
+	EventMsg        chan string
+	AddedReplicaSet chan ReplicaSetAddedReport
+	AddedPod        chan PodAddedReport
+	PodLogChunk     chan *replicaset.ReplicaSetPodLogChunk
+	PodError        chan PodErrorReport
+
+	resourceAdded      chan *unstructured.Unstructured
+	resourceModified   chan *unstructured.Unstructured
+	resourceDeleted    chan *unstructured.Unstructured
+	resourceFailed     chan string
+	replicaSetAdded    chan *appsv1.ReplicaSet
+	replicaSetModified chan *appsv1.ReplicaSet
+	replicaSetDeleted  chan *appsv1.ReplicaSet
+	analysisRunChanged chan AnalysisRunStatus
+	errors             chan error
+
+	podAddedRelay           chan *corev1.Pod
+	podStatusesRelay        chan map[string]pod.PodStatus
+	podLogChunksRelay       chan map[string]*pod.ContainerLogChunk
+	podContainerErrorsRelay chan map[string]pod.ContainerErrorReport
+	donePodsRelay           chan map[string]pod.PodStatus
+}
+
+func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Interface, dynamicClient dynamic.Interface, opts tracker.Options) *Tracker {
+	return &Tracker{
+		Tracker: tracker.Tracker{
+			Kube:             kube,
+			Namespace:        namespace,
+			FullResourceName: fmt.Sprintf("rollout/%s", name),
+			ResourceName:     name,
+			Context:          ctx,
+			LogsFromTime:     opts.LogsFromTime,
+		},
+
+		Dynamic: dynamicClient,
+
+		Added:  make(chan RolloutStatus, 1),
+		Ready:  make(chan RolloutStatus, 0),
+		Failed: make(chan RolloutStatus, 0),
+		Status: make(chan RolloutStatus, 100),
+
+		EventMsg:        make(chan string, 1),
+		AddedReplicaSet: make(chan ReplicaSetAddedReport, 10),
+		AddedPod:        make(chan PodAddedReport, 10),
+		PodLogChunk:     make(chan *replicaset.ReplicaSetPodLogChunk, 1000),
+		PodError:        make(chan PodErrorReport, 0),
+
+		knownReplicaSets: make(map[string]*appsv1.ReplicaSet),
+		podStatuses:      make(map[string]pod.PodStatus),
+		rsNameByPod:      make(map[string]string),
+		analysisRuns:     make(map[string]AnalysisRunStatus),
+
+		errors:             make(chan error, 0),
+		resourceAdded:      make(chan *unstructured.Unstructured, 1),
+		resourceModified:   make(chan *unstructured.Unstructured, 1),
+		resourceDeleted:    make(chan *unstructured.Unstructured, 1),
+		resourceFailed:     make(chan string, 1),
+		replicaSetAdded:    make(chan *appsv1.ReplicaSet, 1),
+		replicaSetModified: make(chan *appsv1.ReplicaSet, 1),
+		replicaSetDeleted:  make(chan *appsv1.ReplicaSet, 1),
+		analysisRunChanged: make(chan AnalysisRunStatus, 1),
+
+		podAddedRelay:           make(chan *corev1.Pod, 1),
+		podStatusesRelay:        make(chan map[string]pod.PodStatus, 10),
+		podLogChunksRelay:       make(chan map[string]*pod.ContainerLogChunk, 10),
+		podContainerErrorsRelay: make(chan map[string]pod.ContainerErrorReport, 10),
+		donePodsRelay:           make(chan map[string]pod.PodStatus, 10),
+	}
+}
+
+func (r *Tracker) Track() error {
+	r.runRolloutInformer()
+
+	for {
+		select {
+		case object := <-r.resourceAdded:
+			r.handleRolloutState(object)
+
+		case object := <-r.resourceModified:
+			r.handleRolloutState(object)
+
+		case <-r.resourceDeleted:
+			r.State = tracker.ResourceDeleted
+			r.lastObject = nil
+			r.knownReplicaSets = make(map[string]*appsv1.ReplicaSet)
+			r.podStatuses = make(map[string]pod.PodStatus)
+			r.rsNameByPod = make(map[string]string)
+			r.analysisRuns = make(map[string]AnalysisRunStatus)
+			r.TrackedPodsNames = nil
+			r.Status <- RolloutStatus{CanaryWeight: -1}
+
+		case reason := <-r.resourceFailed:
+			r.State = tracker.ResourceFailed
+			r.failedReason = reason
+
+			var status RolloutStatus
+			if r.lastObject != nil {
+				status = r.newStatus(r.lastObject)
+			} else {
+				status = RolloutStatus{CanaryWeight: -1, IsFailed: true, FailedReason: reason}
+			}
+			r.Failed <- status
+
+		case rs := <-r.replicaSetAdded:
+			r.knownReplicaSets[rs.Name] = rs
+
+			if r.lastObject != nil {
+				status := r.newStatus(r.lastObject)
+
+				r.AddedReplicaSet <- ReplicaSetAddedReport{
+					ReplicaSet: replicaset.ReplicaSet{
+						Name:  rs.Name,
+						IsNew: r.isReplicaSetNew(status, rs.Name),
+					},
+					RolloutStatus: status,
+				}
+			}
+
+		case rs := <-r.replicaSetModified:
+			r.knownReplicaSets[rs.Name] = rs
+
+		case rs := <-r.replicaSetDeleted:
+			delete(r.knownReplicaSets, rs.Name)
+
+		case run := <-r.analysisRunChanged:
+			prevRun, hasKey := r.analysisRuns[run.Name]
+			r.analysisRuns[run.Name] = run
+
+			isPhaseChanged := !hasKey || prevRun.Phase != run.Phase
+
+			msg := fmt.Sprintf("analysisrun/%s %s", run.Name, run.Phase)
+			if run.Message != "" {
+				msg += fmt.Sprintf(": %s", run.Message)
+			}
+
+			if run.IsFinished() && isPhaseChanged {
+				r.EventMsg <- msg
+			}
+
+			if r.lastObject == nil {
+				break
+			}
+
+			// Failed AnalysisRun of the revision being rolled out fails the Rollout without waiting for the controller to abort it
+			if run.IsFailed() && isPhaseChanged && r.State != tracker.ResourceFailed {
+				if _, isCurrent := r.newStatus(r.lastObject).AnalysisRuns[run.Name]; isCurrent {
+					r.State = tracker.ResourceFailed
+					r.failedReason = msg
+					r.Failed <- r.newStatus(r.lastObject)
+					break
+				}
+			}
+
+			r.handleRolloutState(r.lastObject)
+
+		case pod := <-r.podAddedRelay:
+			rsName := utils.GetPodReplicaSetName(pod)
+			r.rsNameByPod[pod.Name] = rsName
+
+			if r.lastObject != nil {
+				status := r.newStatus(r.lastObject)
+
+				r.AddedPod <- PodAddedReport{
+					ReplicaSetPod: replicaset.ReplicaSetPod{
+						Name: pod.Name,
+						ReplicaSet: replicaset.ReplicaSet{
+							Name:  rsName,
+							IsNew: r.isReplicaSetNew(status, rsName),
+						},
+					},
+					RolloutStatus: status,
+				}
+			}
+
+			if err := r.runPodTracker(pod.Name); err != nil {
+				return err
+			}
+
+		case donePods := <-r.donePodsRelay:
+			var trackedPodsNames []string
+
+		trackedPodsIteration:
+			for _, name := range r.TrackedPodsNames {
+				for donePodName, status := range donePods {
+					if name == donePodName {
+						// This Pod is no more tracked,
+						// but we need to update final
+						// Pod's status
+						if _, hasKey := r.podStatuses[name]; hasKey {
+							r.podStatuses[name] = status
+						}
+						continue trackedPodsIteration
+					}
+				}
+
+				trackedPodsNames = append(trackedPodsNames, name)
+			}
+			r.TrackedPodsNames = trackedPodsNames
+
+			if r.lastObject != nil {
+				r.handleRolloutState(r.lastObject)
+			}
+
+		case podStatuses := <-r.podStatusesRelay:
+			for podName, podStatus := range podStatuses {
+				r.podStatuses[podName] = podStatus
+			}
+			if r.lastObject != nil {
+				r.handleRolloutState(r.lastObject)
+			}
+
+		case podLogChunks := <-r.podLogChunksRelay:
+			if r.lastObject == nil {
+				continue
+			}
+
+			status := r.newStatus(r.lastObject)
+
+			for podName, chunk := range podLogChunks {
+				rsName, hasKey := r.rsNameByPod[podName]
+				if !hasKey {
+					continue
+				}
+
+				// Only logs of the canary and of the stable Pods are streamed
+				if rsName != status.CanaryReplicaSetName && rsName != status.StableReplicaSetName {
+					continue
+				}
+
+				r.PodLogChunk <- &replicaset.ReplicaSetPodLogChunk{
+					PodLogChunk: &pod.PodLogChunk{
+						ContainerLogChunk: chunk,
+						PodName:           podName,
+					},
+					ReplicaSet: replicaset.ReplicaSet{
+						Name:  rsName,
+						IsNew: r.isReplicaSetNew(status, rsName),
+					},
+				}
+			}
+
+		case podContainerErrors := <-r.podContainerErrorsRelay:
+			for podName, containerError := range podContainerErrors {
+				r.podStatuses[podName] = containerError.PodStatus
+			}
+			if r.lastObject != nil {
+				status := r.newStatus(r.lastObject)
+
+				for podName, containerError := range podContainerErrors {
+					rsName, hasKey := r.rsNameByPod[podName]
+					if !hasKey {
+						continue
+					}
+
+					r.PodError <- PodErrorReport{
+						ReplicaSetPodError: replicaset.ReplicaSetPodError{
+							PodError: pod.PodError{
+								ContainerError: containerError.ContainerError,
+								PodName:        podName,
+							},
+							ReplicaSet: replicaset.ReplicaSet{
+								Name:  rsName,
+								IsNew: r.isReplicaSetNew(status, rsName),
+							},
+						},
+						RolloutStatus: status,
+					}
+				}
+			}
+
+		case <-r.Context.Done():
+			if r.Context.Err() == context.Canceled {
+				return nil
+			}
+			return r.Context.Err()
+		case err := <-r.errors:
+			return err
+		}
+	}
+}
+
+func (r *Tracker) newStatus(object *unstructured.Unstructured) RolloutStatus {
+	r.StatusGeneration++
+	return NewRolloutStatus(object, r.StatusGeneration, r.State == tracker.ResourceFailed, r.failedReason, r.podStatuses, r.getNewPodsNames(object), r.analysisRuns)
+}
+
+// isReplicaSetNew returns true for the ReplicaSet of the revision being rolled out
+func (r *Tracker) isReplicaSetNew(status RolloutStatus, rsName string) bool {
+	if rs, hasKey := r.knownReplicaSets[rsName]; hasKey && status.CurrentHash != "" {
+		return rs.Labels[PodTemplateHashLabel] == status.CurrentHash
+	}
+	return rsName == fmt.Sprintf("%s-%s", r.ResourceName, status.CurrentHash)
+}
+
+func (r *Tracker) getNewPodsNames(object *unstructured.Unstructured) []string {
+	res := []string{}
+
+	currentHash, _, _ := unstructured.NestedString(object.Object, "status", "currentPodHash")
+	if currentHash == "" {
+		return res
+	}
+
+	for podName := range r.podStatuses {
+		if rsName, hasKey := r.rsNameByPod[podName]; hasKey {
+			if r.isReplicaSetNew(RolloutStatus{CurrentHash: currentHash}, rsName) {
+				res = append(res, podName)
+			}
+		}
+	}
+
+	return res
+}
+
+// runRolloutInformer watch for Rollout events
+func (r *Tracker) runRolloutInformer() {
+	resourceClient := r.Dynamic.Resource(RolloutsResource).Namespace(r.Namespace)
+
+	tweakListOptions := func(options metav1.ListOptions) metav1.ListOptions {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", r.ResourceName).String()
+		return options
+	}
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return resourceClient.List(tweakListOptions(options))
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return resourceClient.Watch(tweakListOptions(options))
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(r.Context, lw, &unstructured.Unstructured{}, nil, func(e watch.Event) (bool, error) {
+			if debug.Debug() {
+				fmt.Printf("    rollout/%s event: %#v\n", r.ResourceName, e.Type)
+			}
+
+			var object *unstructured.Unstructured
+
+			if e.Type != watch.Error {
+				var ok bool
+				object, ok = e.Object.(*unstructured.Unstructured)
+				if !ok {
+					return true, fmt.Errorf("expected %s to be a *unstructured.Unstructured, got %T", r.ResourceName, e.Object)
+				}
+			}
+
+			switch e.Type {
+			case watch.Added:
+				r.resourceAdded <- object
+			case watch.Modified:
+				r.resourceModified <- object
+			case watch.Deleted:
+				r.resourceDeleted <- object
+			case watch.Error:
+				return true, fmt.Errorf("rollout error: %v", e.Object)
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			r.errors <- fmt.Errorf("rollout/%s informer error: %s", r.ResourceName, err)
+		}
+
+		if debug.Debug() {
+			fmt.Printf("      rollout/%s informer DONE\n", r.ResourceName)
+		}
+	}()
+}
+
+// runAnalysisRunsInformer watch for AnalysisRuns owned by the Rollout
+func (r *Tracker) runAnalysisRunsInformer(object *unstructured.Unstructured) {
+	resourceClient := r.Dynamic.Resource(AnalysisRunsResource).Namespace(r.Namespace)
+	rolloutUID := object.GetUID()
+
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return resourceClient.List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return resourceClient.Watch(options)
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(r.Context, lw, &unstructured.Unstructured{}, nil, func(e watch.Event) (bool, error) {
+			if e.Type == watch.Error {
+				return true, fmt.Errorf("analysisruns watch error: %v", e.Object)
+			}
+
+			object, ok := e.Object.(*unstructured.Unstructured)
+			if !ok {
+				return true, fmt.Errorf("expected *unstructured.Unstructured, got %T", e.Object)
+			}
+
+			if e.Type == watch.Deleted {
+				return false, nil
+			}
+
+			for _, ref := range object.GetOwnerReferences() {
+				if ref.UID == rolloutUID {
+					r.analysisRunChanged <- newAnalysisRunStatus(object)
+					break
+				}
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			r.errors <- fmt.Errorf("rollout/%s analysisruns informer error: %s", r.ResourceName, err)
+		}
+	}()
+}
+
+// runReplicaSetsInformer watch for ReplicaSets of the Rollout
+func (r *Tracker) runReplicaSetsInformer(object *unstructured.Unstructured) {
+	rsInformer := replicaset.NewReplicaSetInformer(&r.Tracker, utils.ControllerAccessor(object))
+	rsInformer.WithChannels(r.replicaSetAdded, r.replicaSetModified, r.replicaSetDeleted, r.errors)
+	rsInformer.Run()
+}
+
+// runPodsInformer watch for Pods of the Rollout
+func (r *Tracker) runPodsInformer(object *unstructured.Unstructured) {
+	podsInformer := pod.NewPodsInformer(&r.Tracker, utils.ControllerAccessor(object))
+	podsInformer.WithChannels(r.podAddedRelay, r.errors)
+	podsInformer.Run()
+}
+
+func (r *Tracker) runPodTracker(podName string) error {
+	errorChan := make(chan error, 0)
+	doneChan := make(chan struct{}, 0)
+
+	ctx, cancelPodCtx := context.WithCancel(r.Context)
+	podTracker := pod.NewTracker(ctx, podName, r.Namespace, r.Kube)
+	if !r.LogsFromTime.IsZero() {
+		podTracker.LogsFromTime = r.LogsFromTime
+	}
+	r.TrackedPodsNames = append(r.TrackedPodsNames, podName)
+
+	go func() {
+		if debug.Debug() {
+			fmt.Printf("Starting Rollout's `%s` Pod `%s` tracker\n", r.ResourceName, podTracker.ResourceName)
+		}
+
+		err := podTracker.Start()
+		if err != nil {
+			errorChan <- err
+		} else {
+			doneChan <- struct{}{}
+		}
+
+		if debug.Debug() {
+			fmt.Printf("Done Rollout's `%s` Pod `%s` tracker\n", r.ResourceName, podTracker.ResourceName)
+		}
+	}()
+
+	go func() {
+		for {
+			select {
+			case status := <-podTracker.Added:
+				r.podStatusesRelay <- map[string]pod.PodStatus{podTracker.ResourceName: status}
+			case status := <-podTracker.Succeeded:
+				r.podStatusesRelay <- map[string]pod.PodStatus{podTracker.ResourceName: status}
+				cancelPodCtx()
+			case report := <-podTracker.Failed:
+				r.podStatusesRelay <- map[string]pod.PodStatus{podTracker.ResourceName: report.PodStatus}
+			case status := <-podTracker.Ready:
+				r.podStatusesRelay <- map[string]pod.PodStatus{podTracker.ResourceName: status}
+			case status := <-podTracker.Status:
+				r.podStatusesRelay <- map[string]pod.PodStatus{podTracker.ResourceName: status}
+
+			case msg := <-podTracker.EventMsg:
+				r.EventMsg <- fmt.Sprintf("po/%s %s", podTracker.ResourceName, msg)
+			case chunk := <-podTracker.ContainerLogChunk:
+				r.podLogChunksRelay <- map[string]*pod.ContainerLogChunk{podTracker.ResourceName: chunk}
+			case report := <-podTracker.ContainerError:
+				r.podContainerErrorsRelay <- map[string]pod.ContainerErrorReport{podTracker.ResourceName: report}
+
+			case err := <-errorChan:
+				r.errors <- err
+				return
+			case <-doneChan:
+				r.donePodsRelay <- map[string]pod.PodStatus{podTracker.ResourceName: podTracker.LastStatus}
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (r *Tracker) handleRolloutState(object *unstructured.Unstructured) {
+	r.lastObject = object
+
+	status := r.newStatus(object)
+
+	switch r.State {
+	case tracker.Initial:
+		r.runPodsInformer(object)
+		r.runReplicaSetsInformer(object)
+		r.runAnalysisRunsInformer(object)
+		r.runEventsInformer(object)
+
+		if status.IsFailed {
+			r.State = tracker.ResourceFailed
+			r.Failed <- status
+		} else if status.IsReady {
+			r.State = tracker.ResourceReady
+			r.Ready <- status
+		} else {
+			r.State = tracker.ResourceAdded
+			r.Added <- status
+		}
+	case tracker.ResourceAdded, tracker.ResourceFailed:
+		if status.IsFailed && r.State == tracker.ResourceFailed {
+			// Degraded and aborted Rollout stays failed until the next retry or update
+			r.Status <- status
+		} else if status.IsFailed {
+			r.State = tracker.ResourceFailed
+			r.Failed <- status
+		} else if status.IsReady {
+			r.State = tracker.ResourceReady
+			r.Ready <- status
+		} else {
+			r.Status <- status
+		}
+	case tracker.ResourceReady:
+		if status.IsFailed {
+			r.State = tracker.ResourceFailed
+			r.Failed <- status
+		} else {
+			r.Status <- status
+		}
+	case tracker.ResourceDeleted:
+		if status.IsFailed {
+			r.State = tracker.ResourceFailed
+			r.Failed <- status
+		} else if status.IsReady {
+			r.State = tracker.ResourceReady
+			r.Ready <- status
+		} else {
+			r.State = tracker.ResourceAdded
+			r.Added <- status
+		}
+	}
+}
+
+// runEventsInformer watch for Rollout events
+func (r *Tracker) runEventsInformer(object *unstructured.Unstructured) {
+	eventInformer := event.NewEventInformer(&r.Tracker, object)
+	eventInformer.WithChannels(r.EventMsg, r.resourceFailed, r.errors)
+	eventInformer.Run()
+}
@@ -0,0 +1,282 @@
+package cronjob
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/client-go/kubernetes"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+)
+
+type Feed interface {
+	OnAdded(func(ready bool) error)
+	OnReady(func() error)
+	OnFailed(func(reason string) error)
+	OnEventMsg(func(msg string) error)
+	OnAddedJob(func(jobName string) error)
+	OnJobSucceeded(func(jobName string) error)
+	OnJobFailed(func(jobName, reason string) error)
+	OnAddedPod(func(jobName, podName string) error)
+	OnPodLogChunk(func(*JobPodLogChunk) error)
+	OnPodError(func(JobPodError) error)
+	OnStatus(func(CronJobStatus) error)
+
+	GetStatus() CronJobStatus
+	Track(name, namespace string, kube kubernetes.Interface, opts tracker.Options) error
+}
+
+func NewFeed() Feed {
+	return &feed{}
+}
+
+type feed struct {
+	OnAddedFunc        func(bool) error
+	OnReadyFunc        func() error
+	OnFailedFunc       func(string) error
+	OnEventMsgFunc     func(string) error
+	OnAddedJobFunc     func(string) error
+	OnJobSucceededFunc func(string) error
+	OnJobFailedFunc    func(string, string) error
+	OnAddedPodFunc     func(string, string) error
+	OnPodLogChunkFunc  func(*JobPodLogChunk) error
+	OnPodErrorFunc     func(JobPodError) error
+	OnStatusFunc       func(CronJobStatus) error
+
+	statusMux sync.Mutex
+	status    CronJobStatus
+}
+
+func (f *feed) OnAdded(function func(bool) error) {
+	f.OnAddedFunc = function
+}
+func (f *feed) OnReady(function func() error) {
+	f.OnReadyFunc = function
+}
+func (f *feed) OnFailed(function func(string) error) {
+	f.OnFailedFunc = function
+}
+func (f *feed) OnEventMsg(function func(string) error) {
+	f.OnEventMsgFunc = function
+}
+func (f *feed) OnAddedJob(function func(string) error) {
+	f.OnAddedJobFunc = function
+}
+func (f *feed) OnJobSucceeded(function func(string) error) {
+	f.OnJobSucceededFunc = function
+}
+func (f *feed) OnJobFailed(function func(string, string) error) {
+	f.OnJobFailedFunc = function
+}
+func (f *feed) OnAddedPod(function func(string, string) error) {
+	f.OnAddedPodFunc = function
+}
+func (f *feed) OnPodLogChunk(function func(*JobPodLogChunk) error) {
+	f.OnPodLogChunkFunc = function
+}
+func (f *feed) OnPodError(function func(JobPodError) error) {
+	f.OnPodErrorFunc = function
+}
+func (f *feed) OnStatus(function func(CronJobStatus) error) {
+	f.OnStatusFunc = function
+}
+
+func (f *feed) Track(name, namespace string, kube kubernetes.Interface, opts tracker.Options) error {
+	errorChan := make(chan error, 0)
+	doneChan := make(chan struct{}, 0)
+
+	parentContext := opts.ParentContext
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(parentContext, opts.Timeout)
+	defer cancel()
+
+	cronJob := NewTracker(ctx, name, namespace, kube, opts)
+
+	go func() {
+		err := cronJob.Track()
+		if err != nil {
+			errorChan <- err
+		} else {
+			doneChan <- struct{}{}
+		}
+	}()
+
+	for {
+		select {
+		case status := <-cronJob.Added:
+			f.setStatus(status)
+
+			if f.OnAddedFunc != nil {
+				err := f.OnAddedFunc(status.IsReady)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case status := <-cronJob.Ready:
+			f.setStatus(status)
+
+			if f.OnReadyFunc != nil {
+				err := f.OnReadyFunc()
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case status := <-cronJob.Failed:
+			f.setStatus(status)
+
+			if f.OnFailedFunc != nil {
+				err := f.OnFailedFunc(status.FailedReason)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case msg := <-cronJob.EventMsg:
+			if debug.Debug() {
+				fmt.Printf("CronJob `%s` event msg: %s\n", cronJob.ResourceName, msg)
+			}
+
+			if f.OnEventMsgFunc != nil {
+				err := f.OnEventMsgFunc(msg)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case report := <-cronJob.AddedJob:
+			f.setStatus(report.CronJobStatus)
+
+			if f.OnAddedJobFunc != nil {
+				err := f.OnAddedJobFunc(report.JobName)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case report := <-cronJob.JobSucceeded:
+			f.setStatus(report.CronJobStatus)
+
+			if f.OnJobSucceededFunc != nil {
+				err := f.OnJobSucceededFunc(report.JobName)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case report := <-cronJob.JobFailed:
+			f.setStatus(report.CronJobStatus)
+
+			if f.OnJobFailedFunc != nil {
+				err := f.OnJobFailedFunc(report.JobName, report.FailedReason)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case report := <-cronJob.AddedPod:
+			f.setStatus(report.CronJobStatus)
+
+			if f.OnAddedPodFunc != nil {
+				err := f.OnAddedPodFunc(report.JobName, report.PodName)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case chunk := <-cronJob.PodLogChunk:
+			if debug.Debug() {
+				fmt.Printf("CronJob's `%s` job `%s` pod `%s` log chunk\n", cronJob.ResourceName, chunk.JobName, chunk.PodName)
+				for _, line := range chunk.LogLines {
+					fmt.Printf("[%s] %s\n", line.Timestamp, line.Message)
+				}
+			}
+
+			if f.OnPodLogChunkFunc != nil {
+				err := f.OnPodLogChunkFunc(chunk)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case report := <-cronJob.PodError:
+			f.setStatus(report.CronJobStatus)
+
+			if f.OnPodErrorFunc != nil {
+				err := f.OnPodErrorFunc(report.JobPodError)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case status := <-cronJob.Status:
+			f.setStatus(status)
+
+			if f.OnStatusFunc != nil {
+				err := f.OnStatusFunc(status)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case err := <-errorChan:
+			return err
+		case <-doneChan:
+			return nil
+		}
+	}
+}
+
+func (f *feed) setStatus(status CronJobStatus) {
+	f.statusMux.Lock()
+	defer f.statusMux.Unlock()
+
+	if status.StatusGeneration > f.status.StatusGeneration {
+		f.status = status
+	}
+}
+
+func (f *feed) GetStatus() CronJobStatus {
+	f.statusMux.Lock()
+	defer f.statusMux.Unlock()
+	return f.status
+}
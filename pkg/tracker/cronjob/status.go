@@ -0,0 +1,66 @@
+package cronjob
+
+import (
+	"sort"
+
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+
+	"github.com/flant/kubedog/pkg/tracker/job"
+	"github.com/flant/kubedog/pkg/utils"
+)
+
+type CronJobStatus struct {
+	batchv1beta1.CronJobStatus
+
+	StatusGeneration uint64
+
+	Schedule        string
+	LastSchedule    string
+	ActiveJobsNames []string
+	IsSuspended     bool
+	Age             string
+
+	WaitingForMessages []string
+
+	IsReady      bool
+	IsFailed     bool
+	FailedReason string
+
+	// Statuses of the Jobs spawned by the CronJob since the tracking start, map by Job name
+	Jobs map[string]job.JobStatus
+}
+
+func NewCronJobStatus(object *batchv1beta1.CronJob, statusGeneration uint64, jobsStatuses map[string]job.JobStatus) CronJobStatus {
+	res := CronJobStatus{
+		CronJobStatus:    object.Status,
+		StatusGeneration: statusGeneration,
+		Schedule:         object.Spec.Schedule,
+		LastSchedule:     "-",
+		Age:              utils.TranslateTimestampSince(object.CreationTimestamp),
+		Jobs:             make(map[string]job.JobStatus),
+	}
+
+	for k, v := range jobsStatuses {
+		res.Jobs[k] = v
+	}
+
+	if object.Status.LastScheduleTime != nil {
+		res.LastSchedule = utils.TranslateTimestampSince(*object.Status.LastScheduleTime)
+	}
+
+	for _, ref := range object.Status.Active {
+		res.ActiveJobsNames = append(res.ActiveJobsNames, ref.Name)
+	}
+	sort.Strings(res.ActiveJobsNames)
+
+	if object.Spec.Suspend != nil && *object.Spec.Suspend {
+		res.IsSuspended = true
+		res.IsFailed = true
+		res.FailedReason = "cronjob is suspended"
+		res.WaitingForMessages = append(res.WaitingForMessages, "suspend->false")
+	} else {
+		res.IsReady = true
+	}
+
+	return res
+}
@@ -0,0 +1,455 @@
+package cronjob
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+	"github.com/flant/kubedog/pkg/tracker/event"
+	"github.com/flant/kubedog/pkg/tracker/job"
+	"github.com/flant/kubedog/pkg/tracker/pod"
+)
+
+type JobAddedReport struct {
+	JobName       string
+	CronJobStatus CronJobStatus
+}
+
+type JobSucceededReport struct {
+	JobName       string
+	CronJobStatus CronJobStatus
+}
+
+type JobFailedReport struct {
+	JobName       string
+	FailedReason  string
+	CronJobStatus CronJobStatus
+}
+
+type JobPodAddedReport struct {
+	JobName       string
+	PodName       string
+	CronJobStatus CronJobStatus
+}
+
+type JobPodLogChunk struct {
+	*pod.PodLogChunk
+	JobName string
+}
+
+type JobPodError struct {
+	pod.PodError
+	JobName string
+}
+
+type JobPodErrorReport struct {
+	JobPodError   JobPodError
+	CronJobStatus CronJobStatus
+}
+
+// Tracker watches the CronJob and follows Jobs spawned by the CronJob since the tracking start
+type Tracker struct {
+	tracker.Tracker
+
+	Added  chan CronJobStatus
+	Ready  chan CronJobStatus
+	Failed chan CronJobStatus
+	Status chan CronJobStatus
+
+	EventMsg     chan string
+	AddedJob     chan JobAddedReport
+	JobSucceeded chan JobSucceededReport
+	JobFailed    chan JobFailedReport
+	AddedPod     chan JobPodAddedReport
+	PodLogChunk  chan *JobPodLogChunk
+	PodError     chan JobPodErrorReport
+
+	State            tracker.TrackerState
+	TrackedJobsNames []string
+
+	lastObject   *batchv1beta1.CronJob
+	jobsStatuses map[string]job.JobStatus
+
+	objectAdded    chan *batchv1beta1.CronJob
+	objectModified chan *batchv1beta1.CronJob
+	objectDeleted  chan *batchv1beta1.CronJob
+	objectFailed   chan string
+	errors         chan error
+
+	jobAddedRelay     chan *batchv1.Job
+	jobStatusesRelay  chan map[string]job.JobStatus
+	jobSucceededRelay chan string
+	jobFailedRelay    chan map[string]string
+	jobPodAddedRelay  chan map[string]string
+	jobPodErrorRelay  chan JobPodError
+}
+
+func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Interface, opts tracker.Options) *Tracker {
+	return &Tracker{
+		Tracker: tracker.Tracker{
+			Kube:             kube,
+			Namespace:        namespace,
+			FullResourceName: fmt.Sprintf("cronjob/%s", name),
+			ResourceName:     name,
+			Context:          ctx,
+			LogsFromTime:     opts.LogsFromTime,
+		},
+
+		Added:  make(chan CronJobStatus, 1),
+		Ready:  make(chan CronJobStatus, 0),
+		Failed: make(chan CronJobStatus, 0),
+		Status: make(chan CronJobStatus, 100),
+
+		EventMsg:     make(chan string, 1),
+		AddedJob:     make(chan JobAddedReport, 10),
+		JobSucceeded: make(chan JobSucceededReport, 0),
+		JobFailed:    make(chan JobFailedReport, 0),
+		AddedPod:     make(chan JobPodAddedReport, 10),
+		PodLogChunk:  make(chan *JobPodLogChunk, 1000),
+		PodError:     make(chan JobPodErrorReport, 0),
+
+		State: tracker.Initial,
+
+		jobsStatuses: make(map[string]job.JobStatus),
+
+		objectAdded:    make(chan *batchv1beta1.CronJob, 0),
+		objectModified: make(chan *batchv1beta1.CronJob, 0),
+		objectDeleted:  make(chan *batchv1beta1.CronJob, 0),
+		objectFailed:   make(chan string, 1),
+		errors:         make(chan error, 0),
+
+		jobAddedRelay:     make(chan *batchv1.Job, 0),
+		jobStatusesRelay:  make(chan map[string]job.JobStatus, 10),
+		jobSucceededRelay: make(chan string, 10),
+		jobFailedRelay:    make(chan map[string]string, 10),
+		jobPodAddedRelay:  make(chan map[string]string, 10),
+		jobPodErrorRelay:  make(chan JobPodError, 10),
+	}
+}
+
+func (cronJob *Tracker) Track() error {
+	cronJob.runInformer()
+
+	for {
+		select {
+		case object := <-cronJob.objectAdded:
+			if err := cronJob.handleCronJobState(object); err != nil {
+				return err
+			}
+
+		case object := <-cronJob.objectModified:
+			if err := cronJob.handleCronJobState(object); err != nil {
+				return err
+			}
+
+		case <-cronJob.objectDeleted:
+			cronJob.State = tracker.ResourceDeleted
+			cronJob.lastObject = nil
+			cronJob.Status <- CronJobStatus{}
+
+		case reason := <-cronJob.objectFailed:
+			status := CronJobStatus{}
+			if cronJob.lastObject != nil {
+				status = cronJob.newStatus()
+			}
+			status.IsFailed = true
+			status.FailedReason = reason
+
+			cronJob.Failed <- status
+
+		case object := <-cronJob.jobAddedRelay:
+			cronJob.TrackedJobsNames = append(cronJob.TrackedJobsNames, object.Name)
+
+			if cronJob.lastObject != nil {
+				cronJob.AddedJob <- JobAddedReport{JobName: object.Name, CronJobStatus: cronJob.newStatus()}
+			}
+
+			cronJob.runJobTracker(object.Name)
+
+		case jobsStatuses := <-cronJob.jobStatusesRelay:
+			for jobName, jobStatus := range jobsStatuses {
+				cronJob.jobsStatuses[jobName] = jobStatus
+			}
+			if cronJob.lastObject != nil {
+				cronJob.Status <- cronJob.newStatus()
+			}
+
+		case jobName := <-cronJob.jobSucceededRelay:
+			if cronJob.lastObject != nil {
+				cronJob.JobSucceeded <- JobSucceededReport{JobName: jobName, CronJobStatus: cronJob.newStatus()}
+			}
+
+		case failures := <-cronJob.jobFailedRelay:
+			if cronJob.lastObject != nil {
+				for jobName, reason := range failures {
+					cronJob.JobFailed <- JobFailedReport{JobName: jobName, FailedReason: reason, CronJobStatus: cronJob.newStatus()}
+				}
+			}
+
+		case pods := <-cronJob.jobPodAddedRelay:
+			if cronJob.lastObject != nil {
+				for podName, jobName := range pods {
+					cronJob.AddedPod <- JobPodAddedReport{JobName: jobName, PodName: podName, CronJobStatus: cronJob.newStatus()}
+				}
+			}
+
+		case podError := <-cronJob.jobPodErrorRelay:
+			if cronJob.lastObject != nil {
+				cronJob.PodError <- JobPodErrorReport{JobPodError: podError, CronJobStatus: cronJob.newStatus()}
+			}
+
+		case <-cronJob.Context.Done():
+			if cronJob.Context.Err() == context.Canceled {
+				return nil
+			}
+			return cronJob.Context.Err()
+		case err := <-cronJob.errors:
+			return err
+		}
+	}
+}
+
+func (cronJob *Tracker) newStatus() CronJobStatus {
+	cronJob.StatusGeneration++
+	return NewCronJobStatus(cronJob.lastObject, cronJob.StatusGeneration, cronJob.jobsStatuses)
+}
+
+func (cronJob *Tracker) runInformer() {
+	tweakListOptions := func(options metav1.ListOptions) metav1.ListOptions {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", cronJob.ResourceName).String()
+		return options
+	}
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return cronJob.Kube.BatchV1beta1().CronJobs(cronJob.Namespace).List(tweakListOptions(options))
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return cronJob.Kube.BatchV1beta1().CronJobs(cronJob.Namespace).Watch(tweakListOptions(options))
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(cronJob.Context, lw, &batchv1beta1.CronJob{}, nil, func(e watch.Event) (bool, error) {
+			if debug.Debug() {
+				fmt.Printf("CronJob `%s` informer event: %#v\n", cronJob.ResourceName, e.Type)
+			}
+
+			var object *batchv1beta1.CronJob
+
+			if e.Type != watch.Error {
+				var ok bool
+				object, ok = e.Object.(*batchv1beta1.CronJob)
+				if !ok {
+					return true, fmt.Errorf("expected %s to be a *batchv1beta1.CronJob, got %T", cronJob.ResourceName, e.Object)
+				}
+			}
+
+			if e.Type == watch.Added {
+				cronJob.objectAdded <- object
+			} else if e.Type == watch.Modified {
+				cronJob.objectModified <- object
+			} else if e.Type == watch.Deleted {
+				cronJob.objectDeleted <- object
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			cronJob.errors <- fmt.Errorf("cronjob informer error: %s", err)
+		}
+
+		if debug.Debug() {
+			fmt.Printf("CronJob `%s` informer done\n", cronJob.ResourceName)
+		}
+	}()
+}
+
+func (cronJob *Tracker) handleCronJobState(object *batchv1beta1.CronJob) error {
+	cronJob.lastObject = object
+	status := cronJob.newStatus()
+
+	switch cronJob.State {
+	case tracker.Initial:
+		if err := cronJob.runJobsInformer(object); err != nil {
+			return err
+		}
+		cronJob.runEventsInformer(object)
+
+		if status.IsFailed {
+			cronJob.State = tracker.ResourceFailed
+			cronJob.Failed <- status
+		} else {
+			cronJob.State = tracker.ResourceReady
+			cronJob.Added <- status
+		}
+	case tracker.ResourceReady:
+		if status.IsFailed {
+			cronJob.State = tracker.ResourceFailed
+			cronJob.Failed <- status
+		} else {
+			cronJob.Status <- status
+		}
+	case tracker.ResourceFailed:
+		if status.IsFailed {
+			cronJob.Status <- status
+		} else {
+			cronJob.State = tracker.ResourceReady
+			cronJob.Ready <- status
+		}
+	case tracker.ResourceDeleted:
+		if status.IsFailed {
+			cronJob.State = tracker.ResourceFailed
+			cronJob.Failed <- status
+		} else {
+			cronJob.State = tracker.ResourceReady
+			cronJob.Ready <- status
+		}
+	}
+
+	return nil
+}
+
+// runJobsInformer watch for new Jobs owned by the CronJob.
+// Jobs existing before the tracking start are ignored.
+func (cronJob *Tracker) runJobsInformer(object *batchv1beta1.CronJob) error {
+	isOwnedJob := func(j *batchv1.Job) bool {
+		for _, ref := range j.OwnerReferences {
+			if ref.UID == object.UID {
+				return true
+			}
+		}
+		return false
+	}
+
+	initialJobs, err := cronJob.Kube.BatchV1().Jobs(cronJob.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to list jobs of cronjob/%s: %s", cronJob.ResourceName, err)
+	}
+
+	knownJobsUIDs := make(map[types.UID]bool)
+	for _, j := range initialJobs.Items {
+		if isOwnedJob(&j) {
+			knownJobsUIDs[j.UID] = true
+		}
+	}
+
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return cronJob.Kube.BatchV1().Jobs(cronJob.Namespace).List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return cronJob.Kube.BatchV1().Jobs(cronJob.Namespace).Watch(options)
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(cronJob.Context, lw, &batchv1.Job{}, nil, func(e watch.Event) (bool, error) {
+			if e.Type != watch.Added {
+				return false, nil
+			}
+
+			object, ok := e.Object.(*batchv1.Job)
+			if !ok {
+				return true, fmt.Errorf("expected *batchv1.Job, got %T", e.Object)
+			}
+
+			if !isOwnedJob(object) || knownJobsUIDs[object.UID] {
+				return false, nil
+			}
+			knownJobsUIDs[object.UID] = true
+
+			if debug.Debug() {
+				fmt.Printf("CronJob `%s` spawned Job `%s`\n", cronJob.ResourceName, object.Name)
+			}
+
+			cronJob.jobAddedRelay <- object
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			cronJob.errors <- fmt.Errorf("cronjob jobs informer error: %s", err)
+		}
+	}()
+
+	return nil
+}
+
+func (cronJob *Tracker) runJobTracker(jobName string) {
+	errorChan := make(chan error, 0)
+	doneChan := make(chan struct{}, 0)
+
+	ctx, cancelJobCtx := context.WithCancel(cronJob.Context)
+	jobTracker := job.NewTracker(ctx, jobName, cronJob.Namespace, cronJob.Kube, tracker.Options{LogsFromTime: cronJob.LogsFromTime})
+
+	go func() {
+		if debug.Debug() {
+			fmt.Printf("Starting CronJob's `%s` Job `%s` tracker\n", cronJob.ResourceName, jobName)
+		}
+
+		if err := jobTracker.Track(); err != nil {
+			errorChan <- err
+		} else {
+			doneChan <- struct{}{}
+		}
+
+		if debug.Debug() {
+			fmt.Printf("Done CronJob's `%s` Job `%s` tracker\n", cronJob.ResourceName, jobName)
+		}
+	}()
+
+	go func() {
+		for {
+			select {
+			case status := <-jobTracker.Added:
+				cronJob.jobStatusesRelay <- map[string]job.JobStatus{jobName: status}
+			case status := <-jobTracker.Status:
+				cronJob.jobStatusesRelay <- map[string]job.JobStatus{jobName: status}
+			case status := <-jobTracker.Succeeded:
+				cronJob.jobStatusesRelay <- map[string]job.JobStatus{jobName: status}
+				cronJob.jobSucceededRelay <- jobName
+				cancelJobCtx()
+			case status := <-jobTracker.Failed:
+				cronJob.jobStatusesRelay <- map[string]job.JobStatus{jobName: status}
+				cronJob.jobFailedRelay <- map[string]string{jobName: status.FailedReason}
+
+			case msg := <-jobTracker.EventMsg:
+				cronJob.EventMsg <- fmt.Sprintf("job/%s %s", jobName, msg)
+			case report := <-jobTracker.AddedPod:
+				cronJob.jobStatusesRelay <- map[string]job.JobStatus{jobName: report.JobStatus}
+				cronJob.jobPodAddedRelay <- map[string]string{report.PodName: jobName}
+			case chunk := <-jobTracker.PodLogChunk:
+				cronJob.PodLogChunk <- &JobPodLogChunk{PodLogChunk: chunk, JobName: jobName}
+			case report := <-jobTracker.PodError:
+				cronJob.jobStatusesRelay <- map[string]job.JobStatus{jobName: report.JobStatus}
+				cronJob.jobPodErrorRelay <- JobPodError{PodError: report.PodError, JobName: jobName}
+
+			case err := <-errorChan:
+				cronJob.errors <- fmt.Errorf("job/%s tracker failed: %s", jobName, err)
+				return
+			case <-doneChan:
+				return
+			}
+		}
+	}()
+}
+
+// runEventsInformer watch for CronJob events
+func (cronJob *Tracker) runEventsInformer(object *batchv1beta1.CronJob) {
+	eventInformer := event.NewEventInformer(&cronJob.Tracker, object)
+	eventInformer.WithChannels(cronJob.EventMsg, cronJob.objectFailed, cronJob.errors)
+	eventInformer.Run()
+}
@@ -426,6 +426,13 @@ func (d *Tracker) handleDaemonSetState(object *appsv1.DaemonSet) error {
 		} else {
 			d.Status <- status
 		}
+	case tracker.ResourceReady:
+		if status.IsFailed {
+			d.State = tracker.ResourceFailed
+			d.Failed <- status
+		} else {
+			d.Status <- status
+		}
 	case tracker.ResourceSucceeded:
 		d.Status <- status
 	case tracker.ResourceDeleted:
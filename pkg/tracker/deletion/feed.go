@@ -0,0 +1,138 @@
+package deletion
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+)
+
+type Feed interface {
+	OnAdded(func() error)
+	OnDeleted(func() error)
+	OnStatus(func(DeletionStatus) error)
+
+	GetStatus() DeletionStatus
+	Track(kind, name, namespace string, gvr schema.GroupVersionResource, trackPods bool, kube kubernetes.Interface, dynamicClient dynamic.Interface, opts tracker.Options) error
+}
+
+func NewFeed() Feed {
+	return &feed{}
+}
+
+type feed struct {
+	OnAddedFunc   func() error
+	OnDeletedFunc func() error
+	OnStatusFunc  func(DeletionStatus) error
+
+	statusMux sync.Mutex
+	status    DeletionStatus
+}
+
+func (f *feed) OnAdded(function func() error) {
+	f.OnAddedFunc = function
+}
+func (f *feed) OnDeleted(function func() error) {
+	f.OnDeletedFunc = function
+}
+func (f *feed) OnStatus(function func(DeletionStatus) error) {
+	f.OnStatusFunc = function
+}
+
+func (f *feed) Track(kind, name, namespace string, gvr schema.GroupVersionResource, trackPods bool, kube kubernetes.Interface, dynamicClient dynamic.Interface, opts tracker.Options) error {
+	errorChan := make(chan error, 0)
+	doneChan := make(chan struct{}, 0)
+
+	parentContext := opts.ParentContext
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(parentContext, opts.Timeout)
+	defer cancel()
+
+	deletionTracker := NewTracker(ctx, kind, name, namespace, gvr, trackPods, kube, dynamicClient, opts)
+
+	go func() {
+		err := deletionTracker.Track()
+		if err != nil {
+			errorChan <- err
+		} else {
+			doneChan <- struct{}{}
+		}
+	}()
+
+	for {
+		select {
+		case status := <-deletionTracker.Added:
+			f.setStatus(status)
+
+			if debug.Debug() {
+				fmt.Printf("%s exists, waiting for deletion\n", deletionTracker.FullResourceName)
+			}
+
+			if f.OnAddedFunc != nil {
+				err := f.OnAddedFunc()
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case status := <-deletionTracker.Deleted:
+			f.setStatus(status)
+
+			if debug.Debug() {
+				fmt.Printf("%s deleted\n", deletionTracker.FullResourceName)
+			}
+
+			if f.OnDeletedFunc != nil {
+				err := f.OnDeletedFunc()
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case status := <-deletionTracker.Status:
+			f.setStatus(status)
+
+			if f.OnStatusFunc != nil {
+				err := f.OnStatusFunc(status)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case err := <-errorChan:
+			return err
+		case <-doneChan:
+			return nil
+		}
+	}
+}
+
+func (f *feed) setStatus(status DeletionStatus) {
+	f.statusMux.Lock()
+	defer f.statusMux.Unlock()
+	f.status = status
+}
+
+func (f *feed) GetStatus() DeletionStatus {
+	f.statusMux.Lock()
+	defer f.statusMux.Unlock()
+	return f.status
+}
@@ -0,0 +1,72 @@
+package deletion
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type PodDeletionStatus struct {
+	Phase         string
+	IsTerminating bool
+	Finalizers    []string
+}
+
+type DeletionStatus struct {
+	StatusGeneration uint64
+
+	IsExists      bool
+	IsTerminating bool
+	Finalizers    []string
+
+	// Pods of the resource which are not deleted yet, map by Pod name
+	Pods map[string]PodDeletionStatus
+
+	WaitingForMessages []string
+
+	IsDeleted bool
+}
+
+// NewDeletionStatus creates status of the resource deletion, object is nil when the resource does not exist
+func NewDeletionStatus(object *unstructured.Unstructured, statusGeneration uint64, pods map[string]*corev1.Pod, isPodsSynced bool) DeletionStatus {
+	res := DeletionStatus{
+		StatusGeneration: statusGeneration,
+		Pods:             make(map[string]PodDeletionStatus),
+	}
+
+	if object != nil {
+		res.IsExists = true
+		res.IsTerminating = object.GetDeletionTimestamp() != nil
+		res.Finalizers = object.GetFinalizers()
+
+		if res.IsTerminating && len(res.Finalizers) > 0 {
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("finalizers %s", strings.Join(res.Finalizers, ", ")))
+		} else if !res.IsTerminating {
+			res.WaitingForMessages = append(res.WaitingForMessages, "resource deletion")
+		}
+	}
+
+	var podsNames []string
+	for name, pod := range pods {
+		res.Pods[name] = PodDeletionStatus{
+			Phase:         string(pod.Status.Phase),
+			IsTerminating: pod.DeletionTimestamp != nil,
+			Finalizers:    pod.Finalizers,
+		}
+		podsNames = append(podsNames, name)
+	}
+	sort.Strings(podsNames)
+
+	if len(podsNames) > 0 {
+		res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("pods %s to be deleted", strings.Join(podsNames, ", ")))
+	} else if !isPodsSynced {
+		res.WaitingForMessages = append(res.WaitingForMessages, "pods list")
+	}
+
+	res.IsDeleted = !res.IsExists && isPodsSynced && len(podsNames) == 0
+
+	return res
+}
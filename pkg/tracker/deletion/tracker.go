@@ -0,0 +1,306 @@
+package deletion
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+	"github.com/flant/kubedog/pkg/utils"
+)
+
+// Tracker waits until the resource and its Pods are deleted.
+// Pods are looked up by the spec.selector of the resource when TrackPods is set.
+type Tracker struct {
+	tracker.Tracker
+
+	Dynamic              dynamic.Interface
+	GroupVersionResource schema.GroupVersionResource
+	TrackPods            bool
+
+	Added   chan DeletionStatus
+	Deleted chan DeletionStatus
+	Status  chan DeletionStatus
+
+	State tracker.TrackerState
+
+	lastObject            *unstructured.Unstructured
+	isObjectSynced        bool
+	pods                  map[string]*corev1.Pod
+	isPodsInformerStarted bool
+	isPodsSynced          bool
+
+	objectSynced   chan *unstructured.Unstructured
+	objectAdded    chan *unstructured.Unstructured
+	objectModified chan *unstructured.Unstructured
+	objectDeleted  chan *unstructured.Unstructured
+	podsSynced     chan []*corev1.Pod
+	podChanged     chan *corev1.Pod
+	podDeleted     chan *corev1.Pod
+	errors         chan error
+}
+
+func NewTracker(ctx context.Context, kind, name, namespace string, gvr schema.GroupVersionResource, trackPods bool, kube kubernetes.Interface, dynamicClient dynamic.Interface, opts tracker.Options) *Tracker {
+	return &Tracker{
+		Tracker: tracker.Tracker{
+			Kube:             kube,
+			Namespace:        namespace,
+			FullResourceName: fmt.Sprintf("%s/%s", kind, name),
+			ResourceName:     name,
+			Context:          ctx,
+			LogsFromTime:     opts.LogsFromTime,
+		},
+
+		Dynamic:              dynamicClient,
+		GroupVersionResource: gvr,
+		TrackPods:            trackPods,
+
+		Added:   make(chan DeletionStatus, 1),
+		Deleted: make(chan DeletionStatus, 0),
+		Status:  make(chan DeletionStatus, 100),
+
+		State: tracker.Initial,
+
+		pods: make(map[string]*corev1.Pod),
+
+		objectSynced:   make(chan *unstructured.Unstructured, 0),
+		objectAdded:    make(chan *unstructured.Unstructured, 0),
+		objectModified: make(chan *unstructured.Unstructured, 0),
+		objectDeleted:  make(chan *unstructured.Unstructured, 0),
+		podsSynced:     make(chan []*corev1.Pod, 0),
+		podChanged:     make(chan *corev1.Pod, 0),
+		podDeleted:     make(chan *corev1.Pod, 0),
+		errors:         make(chan error, 0),
+	}
+}
+
+func (t *Tracker) Track() error {
+	t.runInformer()
+
+	for {
+		select {
+		case object := <-t.objectSynced:
+			t.isObjectSynced = true
+			t.handleObject(object)
+
+		case object := <-t.objectAdded:
+			t.handleObject(object)
+
+		case object := <-t.objectModified:
+			t.handleObject(object)
+
+		case <-t.objectDeleted:
+			t.handleObject(nil)
+
+		case pods := <-t.podsSynced:
+			t.isPodsSynced = true
+			for _, pod := range pods {
+				t.pods[pod.Name] = pod
+			}
+			t.handleDeletionState()
+
+		case pod := <-t.podChanged:
+			t.pods[pod.Name] = pod
+			t.handleDeletionState()
+
+		case pod := <-t.podDeleted:
+			delete(t.pods, pod.Name)
+			t.handleDeletionState()
+
+		case <-t.Context.Done():
+			if t.Context.Err() == context.Canceled {
+				return nil
+			}
+			return t.Context.Err()
+		case err := <-t.errors:
+			return err
+		}
+	}
+}
+
+func (t *Tracker) handleObject(object *unstructured.Unstructured) {
+	t.lastObject = object
+
+	if object != nil && t.TrackPods && !t.isPodsInformerStarted {
+		t.runPodsInformer(object)
+	}
+
+	t.handleDeletionState()
+}
+
+func (t *Tracker) runInformer() {
+	resourceClient := t.Dynamic.Resource(t.GroupVersionResource).Namespace(t.Namespace)
+
+	tweakListOptions := func(options metav1.ListOptions) metav1.ListOptions {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", t.ResourceName).String()
+		return options
+	}
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return resourceClient.List(tweakListOptions(options))
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return resourceClient.Watch(tweakListOptions(options))
+		},
+	}
+
+	// Existence of the resource is known after the initial list,
+	// unbuffered channel keeps the order with the following watch events
+	precondition := func(store cache.Store) (bool, error) {
+		var object *unstructured.Unstructured
+		if items := store.List(); len(items) > 0 {
+			object, _ = items[0].(*unstructured.Unstructured)
+		}
+		t.objectSynced <- object
+		return false, nil
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(t.Context, lw, &unstructured.Unstructured{}, precondition, func(e watch.Event) (bool, error) {
+			if debug.Debug() {
+				fmt.Printf("%s deletion informer event: %#v\n", t.FullResourceName, e.Type)
+			}
+
+			var object *unstructured.Unstructured
+
+			if e.Type != watch.Error {
+				var ok bool
+				object, ok = e.Object.(*unstructured.Unstructured)
+				if !ok {
+					return true, fmt.Errorf("expected %s to be a *unstructured.Unstructured, got %T", t.FullResourceName, e.Object)
+				}
+			}
+
+			if e.Type == watch.Added {
+				t.objectAdded <- object
+			} else if e.Type == watch.Modified {
+				t.objectModified <- object
+			} else if e.Type == watch.Deleted {
+				t.objectDeleted <- object
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			t.errors <- fmt.Errorf("%s informer error: %s", t.FullResourceName, err)
+		}
+
+		if debug.Debug() {
+			fmt.Printf("%s deletion informer done\n", t.FullResourceName)
+		}
+	}()
+}
+
+// runPodsInformer watch for Pods matched by the selector of the resource
+func (t *Tracker) runPodsInformer(object *unstructured.Unstructured) {
+	t.isPodsInformerStarted = true
+
+	labelSelector := utils.ControllerAccessor(object).LabelSelector()
+	if labelSelector == nil || (len(labelSelector.MatchLabels) == 0 && len(labelSelector.MatchExpressions) == 0) {
+		// Resource has no selector: there are no Pods to wait for
+		t.isPodsSynced = true
+		return
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		t.isPodsSynced = true
+		return
+	}
+
+	tweakListOptions := func(options metav1.ListOptions) metav1.ListOptions {
+		options.LabelSelector = selector.String()
+		return options
+	}
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return t.Kube.CoreV1().Pods(t.Namespace).List(tweakListOptions(options))
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return t.Kube.CoreV1().Pods(t.Namespace).Watch(tweakListOptions(options))
+		},
+	}
+
+	precondition := func(store cache.Store) (bool, error) {
+		var pods []*corev1.Pod
+		for _, item := range store.List() {
+			if pod, ok := item.(*corev1.Pod); ok {
+				pods = append(pods, pod)
+			}
+		}
+		t.podsSynced <- pods
+		return false, nil
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(t.Context, lw, &corev1.Pod{}, precondition, func(e watch.Event) (bool, error) {
+			if e.Type == watch.Error {
+				return true, fmt.Errorf("pods watch error: %v", e.Object)
+			}
+
+			object, ok := e.Object.(*corev1.Pod)
+			if !ok {
+				return true, fmt.Errorf("expected *corev1.Pod, got %T", e.Object)
+			}
+
+			if e.Type == watch.Deleted {
+				t.podDeleted <- object
+			} else {
+				t.podChanged <- object
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			t.errors <- fmt.Errorf("%s pods informer error: %s", t.FullResourceName, err)
+		}
+	}()
+}
+
+func (t *Tracker) handleDeletionState() {
+	if !t.isObjectSynced {
+		return
+	}
+
+	t.StatusGeneration++
+
+	status := NewDeletionStatus(t.lastObject, t.StatusGeneration, t.pods, !t.isPodsInformerStarted || t.isPodsSynced)
+
+	switch t.State {
+	case tracker.Initial:
+		if status.IsDeleted {
+			t.State = tracker.ResourceDeleted
+			t.Deleted <- status
+		} else {
+			t.State = tracker.ResourceAdded
+			t.Added <- status
+		}
+	case tracker.ResourceAdded:
+		if status.IsDeleted {
+			t.State = tracker.ResourceDeleted
+			t.Deleted <- status
+		} else {
+			t.Status <- status
+		}
+	case tracker.ResourceDeleted:
+		if !status.IsDeleted {
+			// Resource has been created again
+			t.State = tracker.ResourceAdded
+		}
+		t.Status <- status
+	}
+}
@@ -0,0 +1,131 @@
+package deployment
+
+import (
+	"fmt"
+
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+)
+
+// HPAStatus describes HorizontalPodAutoscaler which targets the Deployment
+type HPAStatus struct {
+	Name string
+
+	CurrentReplicas int32
+	DesiredReplicas int32
+	MinReplicas     int32
+	MaxReplicas     int32
+
+	// Warnings contains messages of ScalingActive=False and AbleToScale=False conditions
+	Warnings []string
+}
+
+func NewHPAStatus(object *autoscalingv2beta1.HorizontalPodAutoscaler) *HPAStatus {
+	res := &HPAStatus{
+		Name:            object.Name,
+		CurrentReplicas: object.Status.CurrentReplicas,
+		DesiredReplicas: object.Status.DesiredReplicas,
+		MinReplicas:     1,
+		MaxReplicas:     object.Spec.MaxReplicas,
+	}
+
+	if object.Spec.MinReplicas != nil {
+		res.MinReplicas = *object.Spec.MinReplicas
+	}
+
+	for _, cond := range object.Status.Conditions {
+		if cond.Type != autoscalingv2beta1.ScalingActive && cond.Type != autoscalingv2beta1.AbleToScale {
+			continue
+		}
+		if cond.Status != corev1.ConditionFalse {
+			continue
+		}
+
+		msg := fmt.Sprintf("%s=False", cond.Type)
+		if cond.Reason != "" {
+			msg += fmt.Sprintf(" %s", cond.Reason)
+		}
+		if cond.Message != "" {
+			msg += fmt.Sprintf(": %s", cond.Message)
+		}
+		res.Warnings = append(res.Warnings, msg)
+	}
+
+	return res
+}
+
+// TargetReplicas returns replicas count the Deployment is being scaled to by the HPA
+func (s *HPAStatus) TargetReplicas() int32 {
+	if s.DesiredReplicas > 0 {
+		return s.DesiredReplicas
+	}
+	return s.CurrentReplicas
+}
+
+func isHPATargetsDeployment(object *autoscalingv2beta1.HorizontalPodAutoscaler, deploymentName string) bool {
+	return object.Spec.ScaleTargetRef.Kind == "Deployment" && object.Spec.ScaleTargetRef.Name == deploymentName
+}
+
+// runHPAInformer watch for HorizontalPodAutoscalers which target the Deployment.
+// HPA tracking is skipped when HPAs cannot be listed: autoscaling/v2beta1 is not served or list and watch of HPAs are not permitted.
+func (d *Tracker) runHPAInformer() {
+	client := d.Kube
+
+	if _, err := client.AutoscalingV2beta1().HorizontalPodAutoscalers(d.Namespace).List(metav1.ListOptions{Limit: 1}); err != nil {
+		if debug.Debug() {
+			fmt.Printf("deploy/%s hpa tracking is skipped: %s\n", d.ResourceName, err)
+		}
+		return
+	}
+
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return client.AutoscalingV2beta1().HorizontalPodAutoscalers(d.Namespace).List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return client.AutoscalingV2beta1().HorizontalPodAutoscalers(d.Namespace).Watch(options)
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(d.Context, lw, &autoscalingv2beta1.HorizontalPodAutoscaler{}, nil, func(e watch.Event) (bool, error) {
+			if e.Type == watch.Error {
+				return true, fmt.Errorf("hpa watch error: %v", e.Object)
+			}
+
+			object, ok := e.Object.(*autoscalingv2beta1.HorizontalPodAutoscaler)
+			if !ok {
+				return true, fmt.Errorf("expected *autoscalingv2beta1.HorizontalPodAutoscaler, got %T", e.Object)
+			}
+
+			if !isHPATargetsDeployment(object, d.ResourceName) {
+				return false, nil
+			}
+
+			if debug.Debug() {
+				fmt.Printf("    deploy/%s hpa/%s event: %#v\n", d.ResourceName, object.Name, e.Type)
+			}
+
+			switch e.Type {
+			case watch.Added, watch.Modified:
+				d.hpaChanged <- object
+			case watch.Deleted:
+				d.hpaDeleted <- object
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			d.errors <- fmt.Errorf("deploy/%s hpa informer error: %s", d.ResourceName, err)
+		}
+	}()
+}
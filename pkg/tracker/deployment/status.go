@@ -19,6 +19,9 @@ type DeploymentStatus struct {
 	UpToDateIndicator  *indicators.Int32EqualConditionIndicator
 	AvailableIndicator *indicators.Int32EqualConditionIndicator
 
+	// HPA is set when HorizontalPodAutoscaler targets the Deployment
+	HPA *HPAStatus
+
 	WaitingForMessages []string
 
 	IsReady      bool
@@ -31,10 +34,11 @@ type DeploymentStatus struct {
 	NewPodsNames []string
 }
 
-func NewDeploymentStatus(object *appsv1.Deployment, statusGeneration uint64, isTrackerFailed bool, trackerFailedReason string, podsStatuses map[string]pod.PodStatus, newPodsNames []string) DeploymentStatus {
+func NewDeploymentStatus(object *appsv1.Deployment, statusGeneration uint64, isTrackerFailed bool, trackerFailedReason string, podsStatuses map[string]pod.PodStatus, newPodsNames []string, hpaStatus *HPAStatus) DeploymentStatus {
 	res := DeploymentStatus{
 		StatusGeneration: statusGeneration,
 		DeploymentStatus: object.Status,
+		HPA:              hpaStatus,
 		Pods:             make(map[string]pod.PodStatus),
 		NewPodsNames:     newPodsNames,
 	}
@@ -66,31 +70,41 @@ processingPodsStatuses:
 			return res
 		}
 
+		// HPA changes spec.replicas during rollout, so the Deployment
+		// is considered ready when it reaches replicas desired by the HPA
+		targetReplicas := *object.Spec.Replicas
+		if hpaStatus != nil && hpaStatus.TargetReplicas() > 0 {
+			targetReplicas = hpaStatus.TargetReplicas()
+		}
+
 		res.ReplicasIndicator = &indicators.Int32EqualConditionIndicator{
 			Value:       object.Status.Replicas,
-			TargetValue: *object.Spec.Replicas,
+			TargetValue: targetReplicas,
 		}
 		res.UpToDateIndicator = &indicators.Int32EqualConditionIndicator{
 			Value:       object.Status.UpdatedReplicas,
-			TargetValue: *object.Spec.Replicas,
+			TargetValue: targetReplicas,
 		}
 		res.AvailableIndicator = &indicators.Int32EqualConditionIndicator{
 			Value:       object.Status.AvailableReplicas,
-			TargetValue: *object.Spec.Replicas,
+			TargetValue: targetReplicas,
 		}
 
 		res.IsReady = true
-		if object.Status.UpdatedReplicas != *object.Spec.Replicas {
+		if object.Status.UpdatedReplicas != targetReplicas {
 			res.IsReady = false
-			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("up-to-date %d->%d", object.Status.UpdatedReplicas, *object.Spec.Replicas))
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("up-to-date %d->%d", object.Status.UpdatedReplicas, targetReplicas))
 		}
-		if object.Status.Replicas != *object.Spec.Replicas {
+		if object.Status.Replicas != targetReplicas {
 			res.IsReady = false
-			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("replicas %d->%d", object.Status.Replicas, *object.Spec.Replicas))
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("replicas %d->%d", object.Status.Replicas, targetReplicas))
 		}
-		if object.Status.AvailableReplicas != *object.Spec.Replicas {
+		if object.Status.AvailableReplicas != targetReplicas {
 			res.IsReady = false
-			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("available %d->%d", object.Status.AvailableReplicas, *object.Spec.Replicas))
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("available %d->%d", object.Status.AvailableReplicas, targetReplicas))
+		}
+		if hpaStatus != nil && *object.Spec.Replicas != targetReplicas {
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("hpa/%s to scale %d->%d", hpaStatus.Name, *object.Spec.Replicas, targetReplicas))
 		}
 	} else {
 		res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("observed generation %d should be >= %d", object.Status.ObservedGeneration, object.Generation))
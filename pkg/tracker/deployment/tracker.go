@@ -17,6 +17,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2beta1 "k8s.io/api/autoscaling/v2beta1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	watchtools "k8s.io/client-go/tools/watch"
@@ -49,6 +50,7 @@ type Tracker struct {
 	failedReason     string
 	podStatuses      map[string]pod.PodStatus
 	rsNameByPod      map[string]string
+	hpaStatus        *HPAStatus
 
 	TrackedPodsNames []string
 
@@ -70,6 +72,8 @@ type Tracker struct {
 	replicaSetAdded    chan *appsv1.ReplicaSet
 	replicaSetModified chan *appsv1.ReplicaSet
 	replicaSetDeleted  chan *appsv1.ReplicaSet
+	hpaChanged         chan *autoscalingv2beta1.HorizontalPodAutoscaler
+	hpaDeleted         chan *autoscalingv2beta1.HorizontalPodAutoscaler
 	errors             chan error
 
 	podAddedRelay           chan *corev1.Pod
@@ -113,6 +117,8 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 		replicaSetAdded:    make(chan *appsv1.ReplicaSet, 1),
 		replicaSetModified: make(chan *appsv1.ReplicaSet, 1),
 		replicaSetDeleted:  make(chan *appsv1.ReplicaSet, 1),
+		hpaChanged:         make(chan *autoscalingv2beta1.HorizontalPodAutoscaler, 1),
+		hpaDeleted:         make(chan *autoscalingv2beta1.HorizontalPodAutoscaler, 1),
 
 		podAddedRelay:           make(chan *corev1.Pod, 1),
 		podStatusesRelay:        make(chan map[string]pod.PodStatus, 10),
@@ -131,6 +137,7 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 // you can define custom stop triggers using custom implementation of ControllerFeed.
 func (d *Tracker) Track() (err error) {
 	d.runDeploymentInformer()
+	d.runHPAInformer()
 
 	for {
 		select {
@@ -164,7 +171,7 @@ func (d *Tracker) Track() (err error) {
 				if err != nil {
 					return err
 				}
-				status = NewDeploymentStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, d.podStatuses, newPodsNames)
+				status = NewDeploymentStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, d.podStatuses, newPodsNames, d.hpaStatus)
 			} else {
 				status = DeploymentStatus{IsFailed: true, FailedReason: reason}
 			}
@@ -184,7 +191,7 @@ func (d *Tracker) Track() (err error) {
 				if err != nil {
 					return err
 				}
-				status := NewDeploymentStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, d.podStatuses, newPodsNames)
+				status := NewDeploymentStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, d.podStatuses, newPodsNames, d.hpaStatus)
 
 				d.AddedReplicaSet <- ReplicaSetAddedReport{
 					ReplicaSet: replicaset.ReplicaSet{
@@ -201,6 +208,28 @@ func (d *Tracker) Track() (err error) {
 		case rs := <-d.replicaSetDeleted:
 			delete(d.knownReplicaSets, rs.Name)
 
+		case hpa := <-d.hpaChanged:
+			hpaStatus := NewHPAStatus(hpa)
+			d.reportNewHPAWarnings(hpaStatus)
+			d.hpaStatus = hpaStatus
+
+			if d.lastObject != nil {
+				if err := d.handleDeploymentState(d.lastObject); err != nil {
+					return err
+				}
+			}
+
+		case hpa := <-d.hpaDeleted:
+			if d.hpaStatus != nil && d.hpaStatus.Name == hpa.Name {
+				d.hpaStatus = nil
+			}
+
+			if d.lastObject != nil {
+				if err := d.handleDeploymentState(d.lastObject); err != nil {
+					return err
+				}
+			}
+
 		case pod := <-d.podAddedRelay:
 			rsName := utils.GetPodReplicaSetName(pod)
 			d.rsNameByPod[pod.Name] = rsName
@@ -215,7 +244,7 @@ func (d *Tracker) Track() (err error) {
 				if err != nil {
 					return err
 				}
-				status := NewDeploymentStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, d.podStatuses, newPodsNames)
+				status := NewDeploymentStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, d.podStatuses, newPodsNames, d.hpaStatus)
 
 				d.AddedPod <- PodAddedReport{
 					ReplicaSetPod: replicaset.ReplicaSetPod{
@@ -307,7 +336,7 @@ func (d *Tracker) Track() (err error) {
 				if err != nil {
 					return err
 				}
-				status := NewDeploymentStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, d.podStatuses, newPodsNames)
+				status := NewDeploymentStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, d.podStatuses, newPodsNames, d.hpaStatus)
 
 				for podName, containerError := range podContainerErrors {
 					rsName, hasKey := d.rsNameByPod[podName]
@@ -349,6 +378,22 @@ func (d *Tracker) Track() (err error) {
 	return err
 }
 
+// reportNewHPAWarnings sends warnings of the HPA which were not reported before
+func (d *Tracker) reportNewHPAWarnings(hpaStatus *HPAStatus) {
+reportingWarnings:
+	for _, warning := range hpaStatus.Warnings {
+		if d.hpaStatus != nil && d.hpaStatus.Name == hpaStatus.Name {
+			for _, prevWarning := range d.hpaStatus.Warnings {
+				if prevWarning == warning {
+					continue reportingWarnings
+				}
+			}
+		}
+
+		d.EventMsg <- fmt.Sprintf("hpa/%s WARNING: %s", hpaStatus.Name, warning)
+	}
+}
+
 func (d *Tracker) getNewPodsNames() ([]string, error) {
 	res := []string{}
 
@@ -515,7 +560,7 @@ func (d *Tracker) handleDeploymentState(object *appsv1.Deployment) error {
 	if err != nil {
 		return err
 	}
-	status := NewDeploymentStatus(object, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, d.podStatuses, newPodsNames)
+	status := NewDeploymentStatus(object, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, d.podStatuses, newPodsNames, d.hpaStatus)
 
 	switch d.State {
 	case tracker.Initial:
@@ -543,6 +588,13 @@ func (d *Tracker) handleDeploymentState(object *appsv1.Deployment) error {
 		} else {
 			d.Status <- status
 		}
+	case tracker.ResourceReady:
+		if status.IsFailed {
+			d.State = tracker.ResourceFailed
+			d.Failed <- status
+		} else {
+			d.Status <- status
+		}
 	case tracker.ResourceSucceeded:
 		d.Status <- status
 	case tracker.ResourceDeleted:
@@ -24,6 +24,8 @@ type EventInformer struct {
 	Messages chan string
 	Failures chan string
 	Errors   chan error
+	// ObjectEvents receives all events of the resource, it is optional
+	ObjectEvents chan *corev1.Event
 
 	initialEventUids map[types.UID]bool
 }
@@ -53,6 +55,11 @@ func (e *EventInformer) WithChannels(msgCh chan string, failCh chan string, erro
 	return e
 }
 
+func (e *EventInformer) WithObjectEventsChannel(objectEvents chan *corev1.Event) *EventInformer {
+	e.ObjectEvents = objectEvents
+	return e
+}
+
 // runEventsInformer watch for StatefulSet events
 func (e *EventInformer) Run() {
 	e.handleInitialEvents()
@@ -162,6 +169,10 @@ func (e *EventInformer) handleEvent(event *corev1.Event) {
 
 	e.Messages <- fmt.Sprintf("%s: %s", reason, event.Message)
 
+	if e.ObjectEvents != nil {
+		e.ObjectEvents <- event
+	}
+
 	if strings.Contains(reason, "Failed") {
 		if debug.Debug() {
 			fmt.Printf("got FAILED EVENT!!! %s %s\n", event.Reason, event.Message)
@@ -0,0 +1,168 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+)
+
+type Feed interface {
+	OnAdded(func(ready bool) error)
+	OnReady(func() error)
+	OnFailed(func(reason string) error)
+	OnEventMsg(func(msg string) error)
+	OnStatus(func(GenericStatus) error)
+
+	GetStatus() GenericStatus
+	Track(kind, name, namespace string, gvr schema.GroupVersionResource, rules Rules, kube kubernetes.Interface, dynamicClient dynamic.Interface, opts tracker.Options) error
+}
+
+func NewFeed() Feed {
+	return &feed{}
+}
+
+type feed struct {
+	OnAddedFunc    func(bool) error
+	OnReadyFunc    func() error
+	OnFailedFunc   func(string) error
+	OnEventMsgFunc func(string) error
+	OnStatusFunc   func(GenericStatus) error
+
+	statusMux sync.Mutex
+	status    GenericStatus
+}
+
+func (f *feed) OnAdded(function func(bool) error) {
+	f.OnAddedFunc = function
+}
+func (f *feed) OnReady(function func() error) {
+	f.OnReadyFunc = function
+}
+func (f *feed) OnFailed(function func(string) error) {
+	f.OnFailedFunc = function
+}
+func (f *feed) OnEventMsg(function func(string) error) {
+	f.OnEventMsgFunc = function
+}
+func (f *feed) OnStatus(function func(GenericStatus) error) {
+	f.OnStatusFunc = function
+}
+
+func (f *feed) Track(kind, name, namespace string, gvr schema.GroupVersionResource, rules Rules, kube kubernetes.Interface, dynamicClient dynamic.Interface, opts tracker.Options) error {
+	errorChan := make(chan error, 0)
+	doneChan := make(chan struct{}, 0)
+
+	parentContext := opts.ParentContext
+	if parentContext == nil {
+		parentContext = context.Background()
+	}
+	ctx, cancel := watchtools.ContextWithOptionalTimeout(parentContext, opts.Timeout)
+	defer cancel()
+
+	genericTracker := NewTracker(ctx, kind, name, namespace, gvr, rules, kube, dynamicClient, opts)
+
+	go func() {
+		err := genericTracker.Track()
+		if err != nil {
+			errorChan <- err
+		} else {
+			doneChan <- struct{}{}
+		}
+	}()
+
+	for {
+		select {
+		case status := <-genericTracker.Added:
+			f.setStatus(status)
+
+			if f.OnAddedFunc != nil {
+				err := f.OnAddedFunc(status.IsReady)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case status := <-genericTracker.Ready:
+			f.setStatus(status)
+
+			if f.OnReadyFunc != nil {
+				err := f.OnReadyFunc()
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case status := <-genericTracker.Failed:
+			f.setStatus(status)
+
+			if f.OnFailedFunc != nil {
+				err := f.OnFailedFunc(status.FailedReason)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case msg := <-genericTracker.EventMsg:
+			if debug.Debug() {
+				fmt.Printf("%s event msg: %s\n", genericTracker.FullResourceName, msg)
+			}
+
+			if f.OnEventMsgFunc != nil {
+				err := f.OnEventMsgFunc(msg)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case status := <-genericTracker.Status:
+			f.setStatus(status)
+
+			if f.OnStatusFunc != nil {
+				err := f.OnStatusFunc(status)
+				if err == tracker.StopTrack {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+		case err := <-errorChan:
+			return err
+		case <-doneChan:
+			return nil
+		}
+	}
+}
+
+func (f *feed) setStatus(status GenericStatus) {
+	f.statusMux.Lock()
+	defer f.statusMux.Unlock()
+	f.status = status
+}
+
+func (f *feed) GetStatus() GenericStatus {
+	f.statusMux.Lock()
+	defer f.statusMux.Unlock()
+	return f.status
+}
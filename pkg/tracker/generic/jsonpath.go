@@ -0,0 +1,254 @@
+package generic
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+var jsonPathRuleOperators = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+// JSONPathRule is an expression against the live object, such as
+// `.status.phase == "Bound"` or `.status.readyReplicas >= .spec.replicas`.
+//
+// Left side of the expression is a JSONPath, right side is a JSONPath or a JSON value.
+// Expression without operator is true when the JSONPath value exists and is not false, empty or zero.
+// Expression is true when any of the values found by the left JSONPath matches.
+type JSONPathRule struct {
+	Expression string
+
+	leftPath   *jsonpath.JSONPath
+	operator   string
+	rightPath  *jsonpath.JSONPath
+	rightValue interface{}
+}
+
+func ParseJSONPathRule(expression string) (*JSONPathRule, error) {
+	rule := &JSONPathRule{Expression: strings.TrimSpace(expression)}
+
+	left, operator, right := splitJSONPathRuleExpression(rule.Expression)
+
+	leftPath, err := parseJSONPath(left)
+	if err != nil {
+		return nil, fmt.Errorf("bad rule %q: %s", expression, err)
+	}
+	rule.leftPath = leftPath
+
+	if operator == "" {
+		return rule, nil
+	}
+	rule.operator = operator
+
+	if right == "" {
+		return nil, fmt.Errorf("bad rule %q: no value specified after %s", expression, operator)
+	}
+
+	if strings.HasPrefix(right, ".") || strings.HasPrefix(right, "{") {
+		rightPath, err := parseJSONPath(right)
+		if err != nil {
+			return nil, fmt.Errorf("bad rule %q: %s", expression, err)
+		}
+		rule.rightPath = rightPath
+	} else if err := json.Unmarshal([]byte(right), &rule.rightValue); err != nil {
+		return nil, fmt.Errorf("bad rule %q: value %s should be a JSONPath or a JSON value: %s", expression, right, err)
+	}
+
+	return rule, nil
+}
+
+func (rule *JSONPathRule) String() string {
+	return rule.Expression
+}
+
+// Evaluate checks the rule against the object, returns the values found by the left JSONPath
+// to show what the rule is waiting for
+func (rule *JSONPathRule) Evaluate(object interface{}) (bool, []interface{}, error) {
+	leftValues, err := findJSONPathValues(rule.leftPath, object)
+	if err != nil {
+		return false, nil, err
+	}
+
+	var rightValues []interface{}
+	if rule.rightPath != nil {
+		rightValues, err = findJSONPathValues(rule.rightPath, object)
+		if err != nil {
+			return false, leftValues, err
+		}
+	} else {
+		rightValues = []interface{}{rule.rightValue}
+	}
+
+	for _, left := range leftValues {
+		if rule.operator == "" {
+			if isTruthy(left) {
+				return true, leftValues, nil
+			}
+			continue
+		}
+
+		for _, right := range rightValues {
+			if compareValues(left, rule.operator, right) {
+				return true, leftValues, nil
+			}
+		}
+	}
+
+	return false, leftValues, nil
+}
+
+func splitJSONPathRuleExpression(expression string) (string, string, string) {
+	depth := 0
+	var quote rune
+
+	for i, r := range expression {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+			continue
+		case r == '"' || r == '\'':
+			quote = r
+			continue
+		case r == '[' || r == '(' || r == '{':
+			depth++
+			continue
+		case r == ']' || r == ')' || r == '}':
+			depth--
+			continue
+		case depth > 0:
+			continue
+		}
+
+		for _, operator := range jsonPathRuleOperators {
+			if strings.HasPrefix(expression[i:], operator) {
+				return strings.TrimSpace(expression[:i]), operator, strings.TrimSpace(expression[i+len(operator):])
+			}
+		}
+	}
+
+	return expression, "", ""
+}
+
+func parseJSONPath(path string) (*jsonpath.JSONPath, error) {
+	if path == "" {
+		return nil, fmt.Errorf("empty JSONPath")
+	}
+
+	if !strings.HasPrefix(path, "{") {
+		path = fmt.Sprintf("{%s}", path)
+	}
+
+	res := jsonpath.New("rule").AllowMissingKeys(true)
+	if err := res.Parse(path); err != nil {
+		return nil, fmt.Errorf("bad JSONPath %s: %s", path, err)
+	}
+
+	return res, nil
+}
+
+func findJSONPathValues(path *jsonpath.JSONPath, object interface{}) ([]interface{}, error) {
+	results, err := path.FindResults(object)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []interface{}
+	for _, result := range results {
+		for _, value := range result {
+			if !value.IsValid() {
+				continue
+			}
+			if value.Kind() == reflect.Interface && value.IsNil() {
+				continue
+			}
+			res = append(res, value.Interface())
+		}
+	}
+
+	return res, nil
+}
+
+func isTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	default:
+		if number, ok := toFloat(value); ok {
+			return number != 0
+		}
+		return true
+	}
+}
+
+func compareValues(left interface{}, operator string, right interface{}) bool {
+	leftNumber, isLeftNumber := toFloat(left)
+	rightNumber, isRightNumber := toFloat(right)
+
+	if isLeftNumber && isRightNumber {
+		switch operator {
+		case "==":
+			return leftNumber == rightNumber
+		case "!=":
+			return leftNumber != rightNumber
+		case ">=":
+			return leftNumber >= rightNumber
+		case "<=":
+			return leftNumber <= rightNumber
+		case ">":
+			return leftNumber > rightNumber
+		case "<":
+			return leftNumber < rightNumber
+		}
+	}
+
+	switch operator {
+	case "==":
+		return reflect.DeepEqual(left, right)
+	case "!=":
+		return !reflect.DeepEqual(left, right)
+	}
+
+	// Only numbers are ordered
+	return false
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+func formatJSONPathValues(values []interface{}) string {
+	if len(values) == 0 {
+		return "<none>"
+	}
+
+	var res []string
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			res = append(res, fmt.Sprintf("%q", s))
+		} else {
+			res = append(res, fmt.Sprintf("%v", value))
+		}
+	}
+	return strings.Join(res, ", ")
+}
@@ -0,0 +1,162 @@
+package generic
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseJSONPathRule(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    string
+	}{
+		{name: "path only", expression: ".status.ready"},
+		{name: "braced path", expression: "{.status.ready}"},
+		{name: "string value", expression: `.status.phase == "Bound"`},
+		{name: "number value", expression: ".status.readyReplicas >= 3"},
+		{name: "path value", expression: ".status.readyReplicas >= .spec.replicas"},
+		{name: "filter with operator inside", expression: `.status.conditions[?(@.type=="Ready")].status == "True"`},
+		{name: "surrounding spaces", expression: "  .status.phase != null  "},
+		{name: "empty expression", expression: "", wantErr: "empty JSONPath"},
+		{name: "no left path", expression: `== "Bound"`, wantErr: "empty JSONPath"},
+		{name: "no value after operator", expression: ".status.phase ==", wantErr: "no value specified after =="},
+		{name: "unquoted string value", expression: ".status.phase == Bound", wantErr: "should be a JSONPath or a JSON value"},
+		{name: "bad path", expression: ".status.conditions[", wantErr: "bad JSONPath"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseJSONPathRule(tt.expression)
+
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, got nil", tt.wantErr)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %q", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if rule.String() != strings.TrimSpace(tt.expression) {
+				t.Fatalf("expected expression %q, got %q", strings.TrimSpace(tt.expression), rule.String())
+			}
+		})
+	}
+}
+
+func TestSplitJSONPathRuleExpression(t *testing.T) {
+	tests := []struct {
+		expression   string
+		wantLeft     string
+		wantOperator string
+		wantRight    string
+	}{
+		{".status.phase", ".status.phase", "", ""},
+		{`.status.phase == "Bound"`, ".status.phase", "==", `"Bound"`},
+		{".a != 1", ".a", "!=", "1"},
+		{".a >= .b", ".a", ">=", ".b"},
+		{".a <= 2", ".a", "<=", "2"},
+		{".a > 2", ".a", ">", "2"},
+		{".a < 2", ".a", "<", "2"},
+		{`.a[?(@.b=="c")].d == "e"`, `.a[?(@.b=="c")].d`, "==", `"e"`},
+		{`{.a[?(@.b>1)]}`, `{.a[?(@.b>1)]}`, "", ""},
+		{`.a == "x==y"`, ".a", "==", `"x==y"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			left, operator, right := splitJSONPathRuleExpression(tt.expression)
+			if left != tt.wantLeft || operator != tt.wantOperator || right != tt.wantRight {
+				t.Fatalf("expected (%q, %q, %q), got (%q, %q, %q)", tt.wantLeft, tt.wantOperator, tt.wantRight, left, operator, right)
+			}
+		})
+	}
+}
+
+func TestJSONPathRuleEvaluate(t *testing.T) {
+	var object interface{}
+	err := json.Unmarshal([]byte(`{
+		"spec": {"replicas": 3, "paused": false},
+		"status": {
+			"phase": "Bound",
+			"readyReplicas": 3,
+			"updatedReplicas": 2,
+			"message": "",
+			"conditions": [
+				{"type": "Ready", "status": "False"},
+				{"type": "Synced", "status": "True"}
+			]
+		}
+	}`), &object)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{`.status.phase == "Bound"`, true},
+		{`.status.phase != "Bound"`, false},
+		{`.status.phase == "Pending"`, false},
+		{".status.readyReplicas >= .spec.replicas", true},
+		{".status.updatedReplicas >= .spec.replicas", false},
+		{".status.readyReplicas == 3", true},
+		{".status.readyReplicas == 3.0", true},
+		{".status.readyReplicas > 3", false},
+		{".status.readyReplicas < 4", true},
+		{`.status.conditions[?(@.type=="Ready")].status == "True"`, false},
+		{`.status.conditions[?(@.type=="Synced")].status == "True"`, true},
+		{`.status.conditions[*].status == "True"`, true},
+		{".status.phase", true},
+		{".status.message", false},
+		{".spec.paused", false},
+		{".status.readyReplicas", true},
+		{".status.missing", false},
+		{".status.missing == null", false},
+		{`.status.phase > "A"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			rule, err := ParseJSONPathRule(tt.expression)
+			if err != nil {
+				t.Fatalf("unexpected parse error: %s", err)
+			}
+
+			got, _, err := rule.Evaluate(object)
+			if err != nil {
+				t.Fatalf("unexpected evaluate error: %s", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatJSONPathValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []interface{}
+		want   string
+	}{
+		{"no values", nil, "<none>"},
+		{"string", []interface{}{"Bound"}, `"Bound"`},
+		{"mixed", []interface{}{"a", float64(1), true}, `"a", 1, true`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatJSONPathValues(tt.values); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package generic
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/flant/kubedog/pkg/tracker/indicators"
+	"github.com/flant/kubedog/pkg/utils"
+)
+
+// ConditionRule matches the condition from the status.conditions of the resource
+type ConditionRule struct {
+	Type string
+	// Status of the condition, "True" by default
+	Status string
+	// Condition matches any reason if Reasons are not specified
+	Reasons []string
+}
+
+func (rule ConditionRule) conditionStatus() string {
+	if rule.Status == "" {
+		return "True"
+	}
+	return rule.Status
+}
+
+func (rule ConditionRule) Match(condition Condition) bool {
+	if condition.Type != rule.Type || condition.Status != rule.conditionStatus() {
+		return false
+	}
+
+	if len(rule.Reasons) == 0 {
+		return true
+	}
+	for _, reason := range rule.Reasons {
+		if reason == condition.Reason {
+			return true
+		}
+	}
+	return false
+}
+
+func (rule ConditionRule) String() string {
+	if len(rule.Reasons) > 0 {
+		return fmt.Sprintf("condition %s->%s %v", rule.Type, rule.conditionStatus(), rule.Reasons)
+	}
+	return fmt.Sprintf("condition %s->%s", rule.Type, rule.conditionStatus())
+}
+
+// Rules define when the resource is ready and when it is failed.
+// Resource is ready when all ReadyConditions and ReadyJSONPaths are matched
+// and failed when any of FailedConditions or FailedJSONPaths is matched.
+type Rules struct {
+	ReadyConditions  []ConditionRule
+	FailedConditions []ConditionRule
+
+	ReadyJSONPaths  []*JSONPathRule
+	FailedJSONPaths []*JSONPathRule
+}
+
+// DefaultRules are used when no readiness rules are specified
+func DefaultRules() Rules {
+	return Rules{
+		ReadyConditions: []ConditionRule{{Type: "Ready", Status: "True"}},
+	}
+}
+
+type Condition struct {
+	Type    string
+	Status  string
+	Reason  string
+	Message string
+}
+
+type ConditionStatus struct {
+	Condition
+
+	Indicator *indicators.StringEqualConditionIndicator
+
+	// IsTracked is true for conditions used in readiness rules
+	IsTracked bool
+}
+
+type GenericStatus struct {
+	StatusGeneration uint64
+
+	Conditions []ConditionStatus
+	Age        string
+
+	WaitingForMessages []string
+
+	IsReady      bool
+	IsFailed     bool
+	FailedReason string
+}
+
+func NewGenericStatus(object *unstructured.Unstructured, statusGeneration uint64, isTrackerFailed bool, trackerFailedReason string, rules Rules) GenericStatus {
+	res := GenericStatus{
+		StatusGeneration: statusGeneration,
+		Age:              utils.TranslateTimestampSince(object.GetCreationTimestamp()),
+	}
+
+	conditions := getConditions(object)
+
+	for _, c := range conditions {
+		cs := ConditionStatus{
+			Condition: c,
+			Indicator: &indicators.StringEqualConditionIndicator{Value: c.Status},
+		}
+
+		for _, rule := range rules.ReadyConditions {
+			if rule.Type == c.Type {
+				cs.IsTracked = true
+				cs.Indicator.TargetValue = rule.conditionStatus()
+			}
+		}
+		for _, rule := range rules.FailedConditions {
+			if rule.Type == c.Type {
+				cs.IsTracked = true
+				cs.Indicator.FailedValue = rule.conditionStatus()
+			}
+		}
+
+		res.Conditions = append(res.Conditions, cs)
+	}
+
+	for _, rule := range rules.FailedConditions {
+		for _, c := range conditions {
+			if rule.Match(c) && !res.IsFailed {
+				res.IsFailed = true
+				res.FailedReason = formatConditionFailedReason(c)
+			}
+		}
+	}
+
+	for _, rule := range rules.FailedJSONPaths {
+		isMatched, values, err := rule.Evaluate(object.Object)
+		if err == nil && isMatched && !res.IsFailed {
+			res.IsFailed = true
+			res.FailedReason = fmt.Sprintf("rule %s matched (got %s)", rule, formatJSONPathValues(values))
+		}
+	}
+
+	isObservedGenerationReady := true
+	if observedGeneration, found, _ := unstructured.NestedInt64(object.Object, "status", "observedGeneration"); found {
+		if observedGeneration < object.GetGeneration() {
+			isObservedGenerationReady = false
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("observed generation %d->%d", observedGeneration, object.GetGeneration()))
+		}
+	}
+
+	isConditionsReady := true
+	for _, rule := range rules.ReadyConditions {
+		isMatched := false
+		for _, c := range conditions {
+			if rule.Match(c) {
+				isMatched = true
+				break
+			}
+		}
+
+		if !isMatched {
+			isConditionsReady = false
+			res.WaitingForMessages = append(res.WaitingForMessages, rule.String())
+		}
+	}
+
+	isJSONPathsReady := true
+	for _, rule := range rules.ReadyJSONPaths {
+		isMatched, values, err := rule.Evaluate(object.Object)
+		if err != nil {
+			isJSONPathsReady = false
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("%s (%s)", rule, err))
+		} else if !isMatched {
+			isJSONPathsReady = false
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("%s (got %s)", rule, formatJSONPathValues(values)))
+		}
+	}
+
+	res.IsReady = isObservedGenerationReady && isConditionsReady && isJSONPathsReady && !res.IsFailed
+
+	if !res.IsReady && !res.IsFailed {
+		res.IsFailed = isTrackerFailed
+		res.FailedReason = trackerFailedReason
+	}
+
+	return res
+}
+
+func getConditions(object *unstructured.Unstructured) []Condition {
+	rawConditions, found, err := unstructured.NestedSlice(object.Object, "status", "conditions")
+	if err != nil || !found {
+		return nil
+	}
+
+	var res []Condition
+	for _, rawCondition := range rawConditions {
+		fields, ok := rawCondition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		c := Condition{}
+		c.Type, _, _ = unstructured.NestedString(fields, "type")
+		c.Status, _, _ = unstructured.NestedString(fields, "status")
+		c.Reason, _, _ = unstructured.NestedString(fields, "reason")
+		c.Message, _, _ = unstructured.NestedString(fields, "message")
+
+		if c.Type == "" {
+			continue
+		}
+
+		res = append(res, c)
+	}
+
+	return res
+}
+
+func formatConditionFailedReason(c Condition) string {
+	res := fmt.Sprintf("condition %s=%s", c.Type, c.Status)
+	if c.Reason != "" {
+		res += fmt.Sprintf(" %s", c.Reason)
+	}
+	if c.Message != "" {
+		res += fmt.Sprintf(": %s", c.Message)
+	}
+	return res
+}
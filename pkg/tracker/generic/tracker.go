@@ -0,0 +1,237 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+	"github.com/flant/kubedog/pkg/tracker/event"
+)
+
+type Tracker struct {
+	tracker.Tracker
+
+	Dynamic              dynamic.Interface
+	GroupVersionResource schema.GroupVersionResource
+	Rules                Rules
+
+	Added  chan GenericStatus
+	Ready  chan GenericStatus
+	Failed chan GenericStatus
+	Status chan GenericStatus
+
+	EventMsg chan string
+
+	State tracker.TrackerState
+
+	lastObject   *unstructured.Unstructured
+	failedReason string
+
+	objectAdded    chan *unstructured.Unstructured
+	objectModified chan *unstructured.Unstructured
+	objectDeleted  chan *unstructured.Unstructured
+	objectFailed   chan string
+	errors         chan error
+}
+
+func NewTracker(ctx context.Context, kind, name, namespace string, gvr schema.GroupVersionResource, rules Rules, kube kubernetes.Interface, dynamicClient dynamic.Interface, opts tracker.Options) *Tracker {
+	if len(rules.ReadyConditions) == 0 && len(rules.ReadyJSONPaths) == 0 {
+		rules.ReadyConditions = DefaultRules().ReadyConditions
+	}
+
+	return &Tracker{
+		Tracker: tracker.Tracker{
+			Kube:             kube,
+			Namespace:        namespace,
+			FullResourceName: fmt.Sprintf("%s/%s", strings.ToLower(kind), name),
+			ResourceName:     name,
+			Context:          ctx,
+			LogsFromTime:     opts.LogsFromTime,
+		},
+
+		Dynamic:              dynamicClient,
+		GroupVersionResource: gvr,
+		Rules:                rules,
+
+		Added:  make(chan GenericStatus, 1),
+		Ready:  make(chan GenericStatus, 0),
+		Failed: make(chan GenericStatus, 0),
+		Status: make(chan GenericStatus, 100),
+
+		EventMsg: make(chan string, 1),
+
+		State: tracker.Initial,
+
+		objectAdded:    make(chan *unstructured.Unstructured, 0),
+		objectModified: make(chan *unstructured.Unstructured, 0),
+		objectDeleted:  make(chan *unstructured.Unstructured, 0),
+		objectFailed:   make(chan string, 1),
+		errors:         make(chan error, 0),
+	}
+}
+
+func (t *Tracker) Track() error {
+	t.runInformer()
+
+	for {
+		select {
+		case object := <-t.objectAdded:
+			t.handleResourceState(object)
+
+		case object := <-t.objectModified:
+			t.handleResourceState(object)
+
+		case reason := <-t.objectFailed:
+			t.State = tracker.ResourceFailed
+			t.failedReason = reason
+
+			var status GenericStatus
+			if t.lastObject != nil {
+				t.StatusGeneration++
+				status = NewGenericStatus(t.lastObject, t.StatusGeneration, true, t.failedReason, t.Rules)
+			} else {
+				status = GenericStatus{IsFailed: true, FailedReason: reason}
+			}
+			t.Failed <- status
+
+		case <-t.objectDeleted:
+			t.State = tracker.ResourceDeleted
+			t.lastObject = nil
+			t.Status <- GenericStatus{}
+
+		case <-t.Context.Done():
+			if t.Context.Err() == context.Canceled {
+				return nil
+			}
+			return t.Context.Err()
+		case err := <-t.errors:
+			return err
+		}
+	}
+}
+
+func (t *Tracker) runInformer() {
+	resourceClient := t.Dynamic.Resource(t.GroupVersionResource).Namespace(t.Namespace)
+
+	tweakListOptions := func(options metav1.ListOptions) metav1.ListOptions {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", t.ResourceName).String()
+		return options
+	}
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return resourceClient.List(tweakListOptions(options))
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return resourceClient.Watch(tweakListOptions(options))
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(t.Context, lw, &unstructured.Unstructured{}, nil, func(e watch.Event) (bool, error) {
+			if debug.Debug() {
+				fmt.Printf("%s informer event: %#v\n", t.FullResourceName, e.Type)
+			}
+
+			var object *unstructured.Unstructured
+
+			if e.Type != watch.Error {
+				var ok bool
+				object, ok = e.Object.(*unstructured.Unstructured)
+				if !ok {
+					return true, fmt.Errorf("expected %s to be a *unstructured.Unstructured, got %T", t.FullResourceName, e.Object)
+				}
+			}
+
+			if e.Type == watch.Added {
+				t.objectAdded <- object
+			} else if e.Type == watch.Modified {
+				t.objectModified <- object
+			} else if e.Type == watch.Deleted {
+				t.objectDeleted <- object
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			t.errors <- fmt.Errorf("%s informer error: %s", t.FullResourceName, err)
+		}
+
+		if debug.Debug() {
+			fmt.Printf("%s informer done\n", t.FullResourceName)
+		}
+	}()
+}
+
+func (t *Tracker) handleResourceState(object *unstructured.Unstructured) {
+	t.lastObject = object
+	t.StatusGeneration++
+
+	status := NewGenericStatus(object, t.StatusGeneration, t.State == tracker.ResourceFailed, t.failedReason, t.Rules)
+	if status.IsFailed {
+		// Failure by the condition or JSONPath rule is kept along with its reason until the resource is ready
+		t.failedReason = status.FailedReason
+	}
+
+	switch t.State {
+	case tracker.Initial:
+		t.runEventsInformer(object)
+
+		if status.IsFailed {
+			t.State = tracker.ResourceFailed
+			t.Failed <- status
+		} else if status.IsReady {
+			t.State = tracker.ResourceReady
+			t.Ready <- status
+		} else {
+			t.State = tracker.ResourceAdded
+			t.Added <- status
+		}
+	case tracker.ResourceAdded, tracker.ResourceFailed:
+		if status.IsFailed && t.State == tracker.ResourceFailed {
+			// Resource stays failed until it is ready, the same failure is not reported again
+			t.Status <- status
+		} else if status.IsFailed {
+			t.State = tracker.ResourceFailed
+			t.Failed <- status
+		} else if status.IsReady {
+			t.State = tracker.ResourceReady
+			t.Ready <- status
+		} else {
+			t.Status <- status
+		}
+	case tracker.ResourceReady:
+		t.Status <- status
+	case tracker.ResourceDeleted:
+		if status.IsFailed {
+			t.State = tracker.ResourceFailed
+			t.Failed <- status
+		} else if status.IsReady {
+			t.State = tracker.ResourceReady
+			t.Ready <- status
+		} else {
+			t.State = tracker.ResourceAdded
+			t.Added <- status
+		}
+	}
+}
+
+// runEventsInformer watch for resource events
+func (t *Tracker) runEventsInformer(object *unstructured.Unstructured) {
+	eventInformer := event.NewEventInformer(&t.Tracker, object)
+	eventInformer.WithChannels(t.EventMsg, t.objectFailed, t.errors)
+	eventInformer.Run()
+}
@@ -0,0 +1,130 @@
+package ingress
+
+import (
+	"fmt"
+	"sort"
+
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+
+	"github.com/flant/kubedog/pkg/tracker/service"
+	"github.com/flant/kubedog/pkg/utils"
+)
+
+type BackendStatus struct {
+	ServiceName string
+
+	IsServiceExists bool
+	ReadyEndpoints  int
+
+	IsReady bool
+}
+
+type IngressStatus struct {
+	networkingv1beta1.IngressStatus
+
+	StatusGeneration uint64
+
+	Hosts     []string
+	Addresses []string
+	Backends  []BackendStatus
+	Age       string
+
+	WaitingForMessages []string
+
+	IsReady      bool
+	IsFailed     bool
+	FailedReason string
+}
+
+func NewIngressStatus(object *networkingv1beta1.Ingress, statusGeneration uint64, existingServices map[string]bool, servicesEndpoints map[string]service.EndpointsInfo, isTrackerFailed bool, trackerFailedReason string) IngressStatus {
+	res := IngressStatus{
+		IngressStatus:    object.Status,
+		StatusGeneration: statusGeneration,
+		Age:              utils.TranslateTimestampSince(object.CreationTimestamp),
+	}
+
+	for _, rule := range object.Spec.Rules {
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+		res.Hosts = appendIfNotExist(res.Hosts, host)
+	}
+
+	for _, ingress := range object.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			res.Addresses = append(res.Addresses, ingress.IP)
+		} else if ingress.Hostname != "" {
+			res.Addresses = append(res.Addresses, ingress.Hostname)
+		}
+	}
+
+	isAddressReady := len(res.Addresses) > 0
+	if !isAddressReady {
+		res.WaitingForMessages = append(res.WaitingForMessages, "load balancer ingress ip or hostname")
+	}
+
+	isBackendsReady := true
+	for _, serviceName := range BackendServicesNames(object) {
+		backend := BackendStatus{
+			ServiceName:     serviceName,
+			IsServiceExists: existingServices[serviceName],
+			ReadyEndpoints:  len(servicesEndpoints[serviceName].ReadyAddresses),
+		}
+		backend.IsReady = backend.IsServiceExists && backend.ReadyEndpoints > 0
+
+		if !backend.IsServiceExists {
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("backend svc/%s to be created", serviceName))
+		} else if backend.ReadyEndpoints == 0 {
+			res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("backend svc/%s ready endpoints", serviceName))
+		}
+
+		if !backend.IsReady {
+			isBackendsReady = false
+		}
+
+		res.Backends = append(res.Backends, backend)
+	}
+
+	res.IsReady = isAddressReady && isBackendsReady
+
+	if !res.IsReady {
+		res.IsFailed = isTrackerFailed
+		res.FailedReason = trackerFailedReason
+	}
+
+	return res
+}
+
+// BackendServicesNames returns sorted names of all Services referenced by the Ingress
+func BackendServicesNames(object *networkingv1beta1.Ingress) []string {
+	var res []string
+
+	if object.Spec.Backend != nil && object.Spec.Backend.ServiceName != "" {
+		res = appendIfNotExist(res, object.Spec.Backend.ServiceName)
+	}
+
+	for _, rule := range object.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.ServiceName != "" {
+				res = appendIfNotExist(res, path.Backend.ServiceName)
+			}
+		}
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+func appendIfNotExist(arr []string, elem string) []string {
+	for _, e := range arr {
+		if e == elem {
+			return arr
+		}
+	}
+	return append(arr, elem)
+}
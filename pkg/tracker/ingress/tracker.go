@@ -0,0 +1,343 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+	"github.com/flant/kubedog/pkg/tracker/event"
+	"github.com/flant/kubedog/pkg/tracker/service"
+)
+
+type serviceChange struct {
+	Name    string
+	Deleted bool
+}
+
+type endpointsChange struct {
+	Name      string
+	Endpoints service.EndpointsInfo
+	Deleted   bool
+}
+
+// Tracker watches the Ingress along with Services and Endpoints of the namespace
+// to check readiness of the Ingress backends
+type Tracker struct {
+	tracker.Tracker
+
+	Added  chan IngressStatus
+	Ready  chan IngressStatus
+	Failed chan IngressStatus
+	Status chan IngressStatus
+
+	EventMsg chan string
+
+	State tracker.TrackerState
+
+	lastObject        *networkingv1beta1.Ingress
+	existingServices  map[string]bool
+	servicesEndpoints map[string]service.EndpointsInfo
+	failedReason      string
+
+	objectAdded      chan *networkingv1beta1.Ingress
+	objectModified   chan *networkingv1beta1.Ingress
+	objectDeleted    chan *networkingv1beta1.Ingress
+	objectFailed     chan string
+	serviceChanged   chan serviceChange
+	endpointsChanged chan endpointsChange
+	errors           chan error
+}
+
+func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Interface, opts tracker.Options) *Tracker {
+	return &Tracker{
+		Tracker: tracker.Tracker{
+			Kube:             kube,
+			Namespace:        namespace,
+			FullResourceName: fmt.Sprintf("ing/%s", name),
+			ResourceName:     name,
+			Context:          ctx,
+			LogsFromTime:     opts.LogsFromTime,
+		},
+
+		Added:  make(chan IngressStatus, 1),
+		Ready:  make(chan IngressStatus, 0),
+		Failed: make(chan IngressStatus, 0),
+		Status: make(chan IngressStatus, 100),
+
+		EventMsg: make(chan string, 1),
+
+		State: tracker.Initial,
+
+		existingServices:  make(map[string]bool),
+		servicesEndpoints: make(map[string]service.EndpointsInfo),
+
+		objectAdded:      make(chan *networkingv1beta1.Ingress, 0),
+		objectModified:   make(chan *networkingv1beta1.Ingress, 0),
+		objectDeleted:    make(chan *networkingv1beta1.Ingress, 0),
+		objectFailed:     make(chan string, 1),
+		serviceChanged:   make(chan serviceChange, 0),
+		endpointsChanged: make(chan endpointsChange, 0),
+		errors:           make(chan error, 0),
+	}
+}
+
+func (ing *Tracker) Track() error {
+	ing.runInformer()
+	ing.runServicesInformer()
+	ing.runEndpointsInformer()
+
+	for {
+		select {
+		case object := <-ing.objectAdded:
+			ing.handleIngressState(object)
+
+		case object := <-ing.objectModified:
+			ing.handleIngressState(object)
+
+		case change := <-ing.serviceChanged:
+			if change.Deleted {
+				delete(ing.existingServices, change.Name)
+			} else {
+				ing.existingServices[change.Name] = true
+			}
+			if ing.lastObject != nil && ing.isBackendService(change.Name) {
+				ing.handleIngressState(ing.lastObject)
+			}
+
+		case change := <-ing.endpointsChanged:
+			if change.Deleted {
+				delete(ing.servicesEndpoints, change.Name)
+			} else {
+				ing.servicesEndpoints[change.Name] = change.Endpoints
+			}
+			if ing.lastObject != nil && ing.isBackendService(change.Name) {
+				ing.handleIngressState(ing.lastObject)
+			}
+
+		case reason := <-ing.objectFailed:
+			ing.State = tracker.ResourceFailed
+			ing.failedReason = reason
+
+			var status IngressStatus
+			if ing.lastObject != nil {
+				status = ing.newStatus(true)
+			} else {
+				status = IngressStatus{IsFailed: true, FailedReason: reason}
+			}
+			ing.Failed <- status
+
+		case <-ing.objectDeleted:
+			ing.State = tracker.ResourceDeleted
+			ing.lastObject = nil
+			ing.Status <- IngressStatus{}
+
+		case <-ing.Context.Done():
+			if ing.Context.Err() == context.Canceled {
+				return nil
+			}
+			return ing.Context.Err()
+		case err := <-ing.errors:
+			return err
+		}
+	}
+}
+
+func (ing *Tracker) newStatus(isTrackerFailed bool) IngressStatus {
+	ing.StatusGeneration++
+	return NewIngressStatus(ing.lastObject, ing.StatusGeneration, ing.existingServices, ing.servicesEndpoints, isTrackerFailed, ing.failedReason)
+}
+
+func (ing *Tracker) isBackendService(serviceName string) bool {
+	for _, name := range BackendServicesNames(ing.lastObject) {
+		if name == serviceName {
+			return true
+		}
+	}
+	return false
+}
+
+func (ing *Tracker) runInformer() {
+	tweakListOptions := func(options metav1.ListOptions) metav1.ListOptions {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", ing.ResourceName).String()
+		return options
+	}
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return ing.Kube.NetworkingV1beta1().Ingresses(ing.Namespace).List(tweakListOptions(options))
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return ing.Kube.NetworkingV1beta1().Ingresses(ing.Namespace).Watch(tweakListOptions(options))
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(ing.Context, lw, &networkingv1beta1.Ingress{}, nil, func(e watch.Event) (bool, error) {
+			if debug.Debug() {
+				fmt.Printf("Ingress `%s` informer event: %#v\n", ing.ResourceName, e.Type)
+			}
+
+			var object *networkingv1beta1.Ingress
+
+			if e.Type != watch.Error {
+				var ok bool
+				object, ok = e.Object.(*networkingv1beta1.Ingress)
+				if !ok {
+					return true, fmt.Errorf("expected %s to be a *networkingv1beta1.Ingress, got %T", ing.ResourceName, e.Object)
+				}
+			}
+
+			if e.Type == watch.Added {
+				ing.objectAdded <- object
+			} else if e.Type == watch.Modified {
+				ing.objectModified <- object
+			} else if e.Type == watch.Deleted {
+				ing.objectDeleted <- object
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			ing.errors <- fmt.Errorf("ingress informer error: %s", err)
+		}
+
+		if debug.Debug() {
+			fmt.Printf("Ingress `%s` informer done\n", ing.ResourceName)
+		}
+	}()
+}
+
+// runServicesInformer watch for Services of the namespace
+func (ing *Tracker) runServicesInformer() {
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return ing.Kube.CoreV1().Services(ing.Namespace).List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return ing.Kube.CoreV1().Services(ing.Namespace).Watch(options)
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(ing.Context, lw, &corev1.Service{}, nil, func(e watch.Event) (bool, error) {
+			if e.Type == watch.Error {
+				return true, fmt.Errorf("services watch error: %v", e.Object)
+			}
+
+			object, ok := e.Object.(*corev1.Service)
+			if !ok {
+				return true, fmt.Errorf("expected *corev1.Service, got %T", e.Object)
+			}
+
+			if e.Type == watch.Added || e.Type == watch.Deleted {
+				ing.serviceChanged <- serviceChange{Name: object.Name, Deleted: e.Type == watch.Deleted}
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			ing.errors <- fmt.Errorf("ingress services informer error: %s", err)
+		}
+	}()
+}
+
+// runEndpointsInformer watch for Endpoints of the namespace
+func (ing *Tracker) runEndpointsInformer() {
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return ing.Kube.CoreV1().Endpoints(ing.Namespace).List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return ing.Kube.CoreV1().Endpoints(ing.Namespace).Watch(options)
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(ing.Context, lw, &corev1.Endpoints{}, nil, func(e watch.Event) (bool, error) {
+			if e.Type == watch.Error {
+				return true, fmt.Errorf("endpoints watch error: %v", e.Object)
+			}
+
+			object, ok := e.Object.(*corev1.Endpoints)
+			if !ok {
+				return true, fmt.Errorf("expected *corev1.Endpoints, got %T", e.Object)
+			}
+
+			ing.endpointsChanged <- endpointsChange{
+				Name:      object.Name,
+				Endpoints: service.EndpointsInfoFromEndpoints(object),
+				Deleted:   e.Type == watch.Deleted,
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			ing.errors <- fmt.Errorf("ingress endpoints informer error: %s", err)
+		}
+	}()
+}
+
+func (ing *Tracker) handleIngressState(object *networkingv1beta1.Ingress) {
+	ing.lastObject = object
+
+	status := ing.newStatus(ing.State == tracker.ResourceFailed)
+
+	switch ing.State {
+	case tracker.Initial:
+		ing.runEventsInformer(object)
+
+		if status.IsFailed {
+			ing.State = tracker.ResourceFailed
+			ing.Failed <- status
+		} else if status.IsReady {
+			ing.State = tracker.ResourceReady
+			ing.Ready <- status
+		} else {
+			ing.State = tracker.ResourceAdded
+			ing.Added <- status
+		}
+	case tracker.ResourceAdded, tracker.ResourceFailed:
+		if status.IsFailed {
+			ing.State = tracker.ResourceFailed
+			ing.Status <- status
+		} else if status.IsReady {
+			ing.State = tracker.ResourceReady
+			ing.Ready <- status
+		} else {
+			ing.Status <- status
+		}
+	case tracker.ResourceReady:
+		ing.Status <- status
+	case tracker.ResourceDeleted:
+		if status.IsFailed {
+			ing.State = tracker.ResourceFailed
+			ing.Failed <- status
+		} else if status.IsReady {
+			ing.State = tracker.ResourceReady
+			ing.Ready <- status
+		} else {
+			ing.State = tracker.ResourceAdded
+			ing.Added <- status
+		}
+	}
+}
+
+// runEventsInformer watch for Ingress events
+func (ing *Tracker) runEventsInformer(object *networkingv1beta1.Ingress) {
+	eventInformer := event.NewEventInformer(&ing.Tracker, object)
+	eventInformer.WithChannels(ing.EventMsg, ing.objectFailed, ing.errors)
+	eventInformer.Run()
+}
@@ -81,6 +81,9 @@ func (f *feed) Track(name, namespace string, kube kubernetes.Interface, opts tra
 	defer cancel()
 
 	pod := NewTracker(ctx, name, namespace, kube)
+	if !opts.LogsFromTime.IsZero() {
+		pod.LogsFromTime = opts.LogsFromTime
+	}
 
 	go func() {
 		err := pod.Start()
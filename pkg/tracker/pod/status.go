@@ -2,6 +2,7 @@ package pod
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/flant/kubedog/pkg/tracker/indicators"
 	"github.com/flant/kubedog/pkg/utils"
@@ -20,12 +21,41 @@ type PodStatus struct {
 	ReadyContainers int32
 	TotalContainers int32
 
+	RestartPolicy corev1.RestartPolicy
+
 	IsReady      bool
 	IsFailed     bool
 	IsSucceeded  bool
 	FailedReason string
 
 	ContainersErrors map[string]string
+	// ContainersFailures is the classified cause of the problem of each failed container
+	ContainersFailures map[string]ContainerFailure
+}
+
+type ContainerFailureReason string
+
+const (
+	ContainerOOMKilled                  ContainerFailureReason = "OOMKilled"
+	ContainerNonZeroExit                ContainerFailureReason = "NonZeroExit"
+	ContainerEvicted                    ContainerFailureReason = "Evicted"
+	ContainerReadinessProbeFailed       ContainerFailureReason = "ReadinessProbeFailed"
+	ContainerLivenessProbeFailed        ContainerFailureReason = "LivenessProbeFailed"
+	ContainerCreateContainerConfigError ContainerFailureReason = "CreateContainerConfigError"
+	ContainerInvalidImageName           ContainerFailureReason = "InvalidImageName"
+)
+
+type ContainerFailure struct {
+	Reason ContainerFailureReason
+	// Message is the termination message, the eviction message, the probe failure or the waiting message of the container
+	Message string
+
+	// ExitCode and Signal are set for OOMKilled and NonZeroExit failures of the current or the previous container instance
+	ExitCode     int32
+	Signal       int32
+	RestartCount int32
+	// MemoryLimit is the memory limit of the container such as 512Mi, empty if there is no limit
+	MemoryLimit string
 }
 
 func NewPodStatus(pod *corev1.Pod, statusGeneration uint64, trackedContainers []string, isTrackerFailed bool, trackerFailedReason string) PodStatus {
@@ -35,6 +65,7 @@ func NewPodStatus(pod *corev1.Pod, statusGeneration uint64, trackedContainers []
 		Age:              utils.TranslateTimestampSince(pod.CreationTimestamp),
 		StatusIndicator:  &indicators.StringEqualConditionIndicator{},
 		StatusGeneration: statusGeneration,
+		RestartPolicy:    pod.Spec.RestartPolicy,
 	}
 
 	for _, cond := range pod.Status.Conditions {
@@ -136,6 +167,7 @@ func NewPodStatus(pod *corev1.Pod, statusGeneration uint64, trackedContainers []
 	}
 
 	setContainersStatusesToPodStatus(&res, pod)
+	setContainersFailuresToPodStatus(&res, pod)
 
 	return res
 }
@@ -152,7 +184,7 @@ func setContainersStatusesToPodStatus(status *PodStatus, pod *corev1.Pod) {
 	for _, cs := range allContainerStatuses {
 		if cs.State.Waiting != nil {
 			switch cs.State.Waiting.Reason {
-			case "ImagePullBackOff", "ErrImagePull", "CrashLoopBackOff":
+			case "ImagePullBackOff", "ErrImagePull", "CrashLoopBackOff", "CreateContainerConfigError", "InvalidImageName":
 				if status.ContainersErrors == nil {
 					status.ContainersErrors = make(map[string]string)
 				}
@@ -162,3 +194,130 @@ func setContainersStatusesToPodStatus(status *PodStatus, pod *corev1.Pod) {
 		}
 	}
 }
+
+// setContainersFailuresToPodStatus classifies problems of the containers by the pod status,
+// the terminated previous instance is taken into account only while the container is not ready
+func setContainersFailuresToPodStatus(status *PodStatus, pod *corev1.Pod) {
+	allContainerStatuses := make([]corev1.ContainerStatus, 0)
+	for _, cs := range pod.Status.InitContainerStatuses {
+		allContainerStatuses = append(allContainerStatuses, cs)
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		allContainerStatuses = append(allContainerStatuses, cs)
+	}
+
+	for _, cs := range allContainerStatuses {
+		failure := ContainerFailure{RestartCount: cs.RestartCount}
+
+		terminated := cs.State.Terminated
+		if terminated == nil && !cs.Ready {
+			terminated = cs.LastTerminationState.Terminated
+		}
+
+		switch {
+		case pod.Status.Reason == "Evicted":
+			failure.Reason = ContainerEvicted
+			failure.Message = pod.Status.Message
+		case cs.State.Waiting != nil && (cs.State.Waiting.Reason == "CreateContainerConfigError" || cs.State.Waiting.Reason == "InvalidImageName"):
+			failure.Reason = ContainerFailureReason(cs.State.Waiting.Reason)
+			failure.Message = cs.State.Waiting.Message
+		case terminated != nil && terminated.Reason == "OOMKilled":
+			failure.Reason = ContainerOOMKilled
+		case terminated != nil && terminated.ExitCode != 0:
+			failure.Reason = ContainerNonZeroExit
+		default:
+			continue
+		}
+
+		if failure.Reason == ContainerOOMKilled || failure.Reason == ContainerNonZeroExit {
+			failure.ExitCode = terminated.ExitCode
+			failure.Signal = terminated.Signal
+			failure.Message = strings.TrimSpace(terminated.Message)
+		}
+		failure.MemoryLimit = containerMemoryLimit(pod, cs.Name)
+
+		if status.ContainersFailures == nil {
+			status.ContainersFailures = make(map[string]ContainerFailure)
+		}
+		status.ContainersFailures[cs.Name] = failure
+	}
+}
+
+// setContainersProbesFailuresToPodStatus sets failures of the probes reported by the events for the containers, which are not ready.
+// Probe failure is more specific than the non-zero exit of the container killed after the failed liveness probe.
+func setContainersProbesFailuresToPodStatus(status *PodStatus, pod *corev1.Pod, probesFailures map[string]ContainerFailure) {
+	allContainerStatuses := make([]corev1.ContainerStatus, 0)
+	for _, cs := range pod.Status.InitContainerStatuses {
+		allContainerStatuses = append(allContainerStatuses, cs)
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		allContainerStatuses = append(allContainerStatuses, cs)
+	}
+
+	for _, cs := range allContainerStatuses {
+		probeFailure, hasKey := probesFailures[cs.Name]
+		if !hasKey || cs.Ready {
+			continue
+		}
+		if probeFailure.Reason == ContainerReadinessProbeFailed && cs.State.Running == nil {
+			continue
+		}
+
+		if failure, hasKey := status.ContainersFailures[cs.Name]; hasKey && failure.Reason != ContainerNonZeroExit {
+			continue
+		}
+
+		probeFailure.RestartCount = cs.RestartCount
+		probeFailure.MemoryLimit = containerMemoryLimit(pod, cs.Name)
+
+		if status.ContainersFailures == nil {
+			status.ContainersFailures = make(map[string]ContainerFailure)
+		}
+		status.ContainersFailures[cs.Name] = probeFailure
+	}
+}
+
+// newContainerProbeFailure returns the failure of the liveness or readiness probe by the Unhealthy event of the pod
+func newContainerProbeFailure(event *corev1.Event) (string, ContainerFailure, bool) {
+	if event.Reason != "Unhealthy" {
+		return "", ContainerFailure{}, false
+	}
+
+	// Field path is spec.containers{name} or spec.initContainers{name}
+	fieldPath := event.InvolvedObject.FieldPath
+	start, end := strings.Index(fieldPath, "{"), strings.LastIndex(fieldPath, "}")
+	if start < 0 || end <= start {
+		return "", ContainerFailure{}, false
+	}
+	containerName := fieldPath[start+1 : end]
+
+	var reason ContainerFailureReason
+	switch {
+	case strings.HasPrefix(event.Message, "Liveness probe failed"):
+		reason = ContainerLivenessProbeFailed
+	case strings.HasPrefix(event.Message, "Readiness probe failed"):
+		reason = ContainerReadinessProbeFailed
+	default:
+		return "", ContainerFailure{}, false
+	}
+
+	message := strings.TrimSpace(event.Message)
+	if parts := strings.SplitN(message, ":", 2); len(parts) == 2 {
+		message = strings.TrimSpace(parts[1])
+	}
+
+	return containerName, ContainerFailure{Reason: reason, Message: message}, true
+}
+
+func containerMemoryLimit(pod *corev1.Pod, containerName string) string {
+	allContainers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range allContainers {
+		if container.Name != containerName {
+			continue
+		}
+		if limit, hasKey := container.Resources.Limits[corev1.ResourceMemory]; hasKey {
+			return limit.String()
+		}
+	}
+	return ""
+}
@@ -31,6 +31,44 @@ type ContainerError struct {
 type ContainerLogChunk struct {
 	ContainerName string
 	LogLines      []display.LogLine
+
+	// PreviousInstance is set when the chunk contains logs of the terminated instance of the restarted container
+	PreviousInstance *ContainerTermination
+}
+
+type ContainerTermination struct {
+	RestartCount int32
+	ExitCode     int32
+	Signal       int32
+	Reason       string
+	Message      string
+}
+
+// Label returns the container name, the logs of the previous instance are labelled with the exit code and the reason
+func (chunk *ContainerLogChunk) Label() string {
+	if chunk.PreviousInstance == nil {
+		return chunk.ContainerName
+	}
+
+	termination := chunk.PreviousInstance
+	label := fmt.Sprintf("%s (previous instance, exit code %d", chunk.ContainerName, termination.ExitCode)
+	if termination.Signal != 0 {
+		label += fmt.Sprintf(", signal %d", termination.Signal)
+	}
+	if termination.Reason != "" {
+		label += fmt.Sprintf(", %s", termination.Reason)
+	}
+	return label + ")"
+}
+
+// DisplayLogLines returns log lines of the chunk followed by the termination message of the previous instance
+func (chunk *ContainerLogChunk) DisplayLogLines() []display.LogLine {
+	if chunk.PreviousInstance == nil || chunk.PreviousInstance.Message == "" {
+		return chunk.LogLines
+	}
+
+	logLines := append([]display.LogLine{}, chunk.LogLines...)
+	return append(logLines, display.LogLine{Message: fmt.Sprintf("Termination message: %s", chunk.PreviousInstance.Message)})
 }
 
 type PodLogChunk struct {
@@ -79,10 +117,14 @@ type Tracker struct {
 	lastObject   *corev1.Pod
 	failedReason string
 
+	processedContainerTerminations map[string]string
+	containersProbesFailures       map[string]ContainerFailure
+
 	objectAdded    chan *corev1.Pod
 	objectModified chan *corev1.Pod
 	objectDeleted  chan *corev1.Pod
 	objectFailed   chan string
+	objectEvent    chan *corev1.Event
 
 	containerDone chan string
 	errors        chan error
@@ -113,10 +155,14 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 		ProcessedContainerLogTimestamps: make(map[string]time.Time),
 		LogsFromTime:                    time.Time{},
 
+		processedContainerTerminations: make(map[string]string),
+		containersProbesFailures:       make(map[string]ContainerFailure),
+
 		objectAdded:    make(chan *corev1.Pod, 0),
 		objectModified: make(chan *corev1.Pod, 0),
 		objectDeleted:  make(chan *corev1.Pod, 0),
 		objectFailed:   make(chan string, 1),
+		objectEvent:    make(chan *corev1.Event, 10),
 		errors:         make(chan error, 0),
 		containerDone:  make(chan string, 10),
 	}
@@ -145,6 +191,8 @@ func (pod *Tracker) Start() error {
 			pod.lastObject = nil
 			pod.ContainerTrackerStates = make(map[string]tracker.TrackerState)
 			pod.ProcessedContainerLogTimestamps = make(map[string]time.Time)
+			pod.processedContainerTerminations = make(map[string]string)
+			pod.containersProbesFailures = make(map[string]ContainerFailure)
 			status := PodStatus{}
 			pod.LastStatus = status
 
@@ -166,6 +214,7 @@ func (pod *Tracker) Start() error {
 			if pod.lastObject != nil {
 				pod.StatusGeneration++
 				status = NewPodStatus(pod.lastObject, pod.StatusGeneration, pod.TrackedContainers, pod.State == tracker.ResourceFailed, pod.failedReason)
+				setContainersProbesFailuresToPodStatus(&status, pod.lastObject, pod.containersProbesFailures)
 			} else {
 				status = PodStatus{IsFailed: true, FailedReason: reason}
 			}
@@ -173,6 +222,22 @@ func (pod *Tracker) Start() error {
 			pod.LastStatus = status
 			pod.Failed <- FailedReport{PodStatus: status, FailedReason: reason}
 
+		case event := <-pod.objectEvent:
+			containerName, probeFailure, ok := newContainerProbeFailure(event)
+			if !ok {
+				break
+			}
+			pod.containersProbesFailures[containerName] = probeFailure
+
+			// Only the status is updated, containers errors have been already reported by the pod state
+			if pod.lastObject != nil {
+				pod.StatusGeneration++
+				status := NewPodStatus(pod.lastObject, pod.StatusGeneration, pod.TrackedContainers, pod.State == tracker.ResourceFailed, pod.failedReason)
+				setContainersProbesFailuresToPodStatus(&status, pod.lastObject, pod.containersProbesFailures)
+				pod.LastStatus = status
+				pod.Status <- status
+			}
+
 		case containerName := <-pod.containerDone:
 			trackedContainers := make([]string, 0)
 			for _, name := range pod.TrackedContainers {
@@ -204,6 +269,7 @@ func (pod *Tracker) handlePodState(object *corev1.Pod) error {
 	pod.StatusGeneration++
 
 	status := NewPodStatus(object, pod.StatusGeneration, pod.TrackedContainers, pod.State == tracker.ResourceFailed, pod.failedReason)
+	setContainersProbesFailuresToPodStatus(&status, object, pod.containersProbesFailures)
 	pod.LastStatus = status
 
 	if err := pod.handleContainersState(object); err != nil {
@@ -301,6 +367,18 @@ func (pod *Tracker) handleContainersState(object *corev1.Pod) error {
 			pod.ContainerTrackerStates[cs.Name] = tracker.FollowingContainerLogs
 		}
 
+		if terminated := cs.LastTerminationState.Terminated; terminated != nil {
+			// Each restart of the container changes the last termination state
+			terminationID := fmt.Sprintf("%d %s %s", cs.RestartCount, terminated.ContainerID, terminated.FinishedAt.UTC())
+			if pod.processedContainerTerminations[cs.Name] != terminationID {
+				pod.processedContainerTerminations[cs.Name] = terminationID
+
+				if pod.LogsFromTime.IsZero() || !terminated.FinishedAt.Time.Before(pod.LogsFromTime) {
+					go pod.fetchPreviousContainerLogs(cs.Name, cs.RestartCount, *terminated)
+				}
+			}
+		}
+
 		if debug.Debug() {
 			if oldState != pod.ContainerTrackerStates[cs.Name] {
 				fmt.Printf("pod/%s container/%s state changed %#v -> %#v\n", pod.ResourceName, cs.Name, oldState, pod.ContainerTrackerStates[cs.Name])
@@ -347,9 +425,8 @@ func (pod *Tracker) followContainerLogs(containerName string) error {
 					line := string(lineBuf)
 					lineBuf = lineBuf[:0]
 
-					lineParts := strings.SplitN(line, " ", 2)
-					if len(lineParts) == 2 {
-						chunkLines = append(chunkLines, display.LogLine{Timestamp: lineParts[0], Message: lineParts[1]})
+					if logLine, ok := parseLogLine(line); ok {
+						chunkLines = append(chunkLines, logLine)
 					}
 
 					continue
@@ -382,6 +459,65 @@ func (pod *Tracker) followContainerLogs(containerName string) error {
 	return nil
 }
 
+// fetchPreviousContainerLogs sends logs of the terminated instance of the restarted container,
+// these logs often contain the cause of the crash, which has not been caught by the logs stream
+func (pod *Tracker) fetchPreviousContainerLogs(containerName string, restartCount int32, terminated corev1.ContainerStateTerminated) {
+	logOpts := &corev1.PodLogOptions{
+		Container:  containerName,
+		Timestamps: true,
+		Previous:   true,
+	}
+	if !pod.LogsFromTime.IsZero() {
+		logOpts.SinceTime = &metav1.Time{
+			Time: pod.LogsFromTime,
+		}
+	}
+
+	data, err := pod.Kube.CoreV1().
+		Pods(pod.Namespace).
+		GetLogs(pod.ResourceName, logOpts).
+		DoRaw()
+	if err != nil {
+		if debug.Debug() {
+			fmt.Fprintf(os.Stderr, "pod/%s container/%s previous logs error: %s\n", pod.ResourceName, containerName, err)
+		}
+		return
+	}
+
+	chunkLines := make([]display.LogLine, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		if logLine, ok := parseLogLine(line); ok {
+			chunkLines = append(chunkLines, logLine)
+		}
+	}
+
+	chunk := &ContainerLogChunk{
+		ContainerName: containerName,
+		LogLines:      chunkLines,
+		PreviousInstance: &ContainerTermination{
+			RestartCount: restartCount,
+			ExitCode:     terminated.ExitCode,
+			Signal:       terminated.Signal,
+			Reason:       terminated.Reason,
+			Message:      strings.TrimSpace(terminated.Message),
+		},
+	}
+
+	select {
+	case pod.ContainerLogChunk <- chunk:
+	case <-pod.Context.Done():
+	}
+}
+
+// parseLogLine parses the log line with the timestamp prefix
+func parseLogLine(line string) (display.LogLine, bool) {
+	lineParts := strings.SplitN(line, " ", 2)
+	if len(lineParts) != 2 {
+		return display.LogLine{}, false
+	}
+	return display.LogLine{Timestamp: lineParts[0], Message: lineParts[1]}, true
+}
+
 func (pod *Tracker) trackContainer(containerName string) error {
 	ticker := time.NewTicker(200 * time.Millisecond)
 	defer ticker.Stop()
@@ -506,5 +642,6 @@ func (pod *Tracker) runInformer() error {
 func (pod *Tracker) runEventsInformer() {
 	eventInformer := event.NewEventInformer(&pod.Tracker, pod.lastObject)
 	eventInformer.WithChannels(pod.EventMsg, pod.objectFailed, pod.errors)
+	eventInformer.WithObjectEventsChannel(pod.objectEvent)
 	eventInformer.Run()
 }
@@ -0,0 +1,80 @@
+package pvc
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/flant/kubedog/pkg/tracker/indicators"
+	"github.com/flant/kubedog/pkg/utils"
+)
+
+type PVCStatus struct {
+	corev1.PersistentVolumeClaimStatus
+
+	StatusGeneration uint64
+
+	PhaseIndicator *indicators.StringEqualConditionIndicator
+	VolumeName     string
+	Capacity       string
+	AccessModes    string
+	StorageClass   string
+	Age            string
+
+	WaitingForMessages []string
+
+	IsReady      bool
+	IsFailed     bool
+	FailedReason string
+}
+
+func NewPVCStatus(object *corev1.PersistentVolumeClaim, statusGeneration uint64, isTrackerFailed bool, trackerFailedReason string) PVCStatus {
+	res := PVCStatus{
+		PersistentVolumeClaimStatus: object.Status,
+		StatusGeneration:            statusGeneration,
+		PhaseIndicator: &indicators.StringEqualConditionIndicator{
+			Value:       string(object.Status.Phase),
+			TargetValue: string(corev1.ClaimBound),
+			FailedValue: string(corev1.ClaimLost),
+		},
+		VolumeName: object.Spec.VolumeName,
+		Capacity:   "-",
+		Age:        utils.TranslateTimestampSince(object.CreationTimestamp),
+	}
+
+	if res.PhaseIndicator.Value == "" {
+		res.PhaseIndicator.Value = string(corev1.ClaimPending)
+	}
+
+	if storage, hasKey := object.Status.Capacity[corev1.ResourceStorage]; hasKey {
+		res.Capacity = storage.String()
+	}
+
+	var accessModes []string
+	for _, mode := range object.Status.AccessModes {
+		accessModes = append(accessModes, string(mode))
+	}
+	res.AccessModes = strings.Join(accessModes, ",")
+
+	if object.Spec.StorageClassName != nil {
+		res.StorageClass = *object.Spec.StorageClassName
+	}
+
+	switch object.Status.Phase {
+	case corev1.ClaimBound:
+		res.IsReady = true
+	case corev1.ClaimLost:
+		res.IsFailed = true
+		res.FailedReason = fmt.Sprintf("claim lost its volume %s", object.Spec.VolumeName)
+	default:
+		res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("phase %s->%s", res.PhaseIndicator.Value, corev1.ClaimBound))
+	}
+
+	if !res.IsReady && !res.IsFailed {
+		res.IsFailed = isTrackerFailed
+		res.FailedReason = trackerFailedReason
+	}
+
+	return res
+}
@@ -0,0 +1,221 @@
+package pvc
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+	"github.com/flant/kubedog/pkg/tracker/event"
+)
+
+// Tracker watches the PersistentVolumeClaim until it is bound
+type Tracker struct {
+	tracker.Tracker
+
+	Added  chan PVCStatus
+	Ready  chan PVCStatus
+	Failed chan PVCStatus
+	Status chan PVCStatus
+
+	EventMsg chan string
+
+	State tracker.TrackerState
+
+	lastObject   *corev1.PersistentVolumeClaim
+	failedReason string
+
+	objectAdded    chan *corev1.PersistentVolumeClaim
+	objectModified chan *corev1.PersistentVolumeClaim
+	objectDeleted  chan *corev1.PersistentVolumeClaim
+	objectFailed   chan string
+	errors         chan error
+}
+
+func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Interface, opts tracker.Options) *Tracker {
+	return &Tracker{
+		Tracker: tracker.Tracker{
+			Kube:             kube,
+			Namespace:        namespace,
+			FullResourceName: fmt.Sprintf("pvc/%s", name),
+			ResourceName:     name,
+			Context:          ctx,
+			LogsFromTime:     opts.LogsFromTime,
+		},
+
+		Added:  make(chan PVCStatus, 1),
+		Ready:  make(chan PVCStatus, 0),
+		Failed: make(chan PVCStatus, 0),
+		Status: make(chan PVCStatus, 100),
+
+		EventMsg: make(chan string, 1),
+
+		State: tracker.Initial,
+
+		objectAdded:    make(chan *corev1.PersistentVolumeClaim, 0),
+		objectModified: make(chan *corev1.PersistentVolumeClaim, 0),
+		objectDeleted:  make(chan *corev1.PersistentVolumeClaim, 0),
+		objectFailed:   make(chan string, 1),
+		errors:         make(chan error, 0),
+	}
+}
+
+func (pvc *Tracker) Track() error {
+	pvc.runInformer()
+
+	for {
+		select {
+		case object := <-pvc.objectAdded:
+			pvc.handlePVCState(object)
+
+		case object := <-pvc.objectModified:
+			pvc.handlePVCState(object)
+
+		case reason := <-pvc.objectFailed:
+			pvc.State = tracker.ResourceFailed
+			pvc.failedReason = reason
+
+			var status PVCStatus
+			if pvc.lastObject != nil {
+				pvc.StatusGeneration++
+				status = NewPVCStatus(pvc.lastObject, pvc.StatusGeneration, true, pvc.failedReason)
+			} else {
+				status = PVCStatus{IsFailed: true, FailedReason: reason}
+			}
+			pvc.Failed <- status
+
+		case <-pvc.objectDeleted:
+			pvc.State = tracker.ResourceDeleted
+			pvc.lastObject = nil
+			pvc.Status <- PVCStatus{}
+
+		case <-pvc.Context.Done():
+			if pvc.Context.Err() == context.Canceled {
+				return nil
+			}
+			return pvc.Context.Err()
+		case err := <-pvc.errors:
+			return err
+		}
+	}
+}
+
+func (pvc *Tracker) runInformer() {
+	tweakListOptions := func(options metav1.ListOptions) metav1.ListOptions {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", pvc.ResourceName).String()
+		return options
+	}
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return pvc.Kube.CoreV1().PersistentVolumeClaims(pvc.Namespace).List(tweakListOptions(options))
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return pvc.Kube.CoreV1().PersistentVolumeClaims(pvc.Namespace).Watch(tweakListOptions(options))
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(pvc.Context, lw, &corev1.PersistentVolumeClaim{}, nil, func(e watch.Event) (bool, error) {
+			if debug.Debug() {
+				fmt.Printf("PersistentVolumeClaim `%s` informer event: %#v\n", pvc.ResourceName, e.Type)
+			}
+
+			var object *corev1.PersistentVolumeClaim
+
+			if e.Type != watch.Error {
+				var ok bool
+				object, ok = e.Object.(*corev1.PersistentVolumeClaim)
+				if !ok {
+					return true, fmt.Errorf("expected %s to be a *corev1.PersistentVolumeClaim, got %T", pvc.ResourceName, e.Object)
+				}
+			}
+
+			if e.Type == watch.Added {
+				pvc.objectAdded <- object
+			} else if e.Type == watch.Modified {
+				pvc.objectModified <- object
+			} else if e.Type == watch.Deleted {
+				pvc.objectDeleted <- object
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			pvc.errors <- fmt.Errorf("pvc informer error: %s", err)
+		}
+
+		if debug.Debug() {
+			fmt.Printf("PersistentVolumeClaim `%s` informer done\n", pvc.ResourceName)
+		}
+	}()
+}
+
+func (pvc *Tracker) handlePVCState(object *corev1.PersistentVolumeClaim) {
+	pvc.lastObject = object
+	pvc.StatusGeneration++
+
+	status := NewPVCStatus(object, pvc.StatusGeneration, pvc.State == tracker.ResourceFailed, pvc.failedReason)
+
+	switch pvc.State {
+	case tracker.Initial:
+		pvc.runEventsInformer(object)
+
+		if status.IsFailed {
+			pvc.State = tracker.ResourceFailed
+			pvc.Failed <- status
+		} else if status.IsReady {
+			pvc.State = tracker.ResourceReady
+			pvc.Ready <- status
+		} else {
+			pvc.State = tracker.ResourceAdded
+			pvc.Added <- status
+		}
+	case tracker.ResourceAdded, tracker.ResourceFailed:
+		if status.IsFailed && pvc.State == tracker.ResourceFailed {
+			pvc.Status <- status
+		} else if status.IsFailed {
+			pvc.State = tracker.ResourceFailed
+			pvc.Failed <- status
+		} else if status.IsReady {
+			pvc.State = tracker.ResourceReady
+			pvc.Ready <- status
+		} else {
+			pvc.Status <- status
+		}
+	case tracker.ResourceReady:
+		if status.IsFailed {
+			pvc.State = tracker.ResourceFailed
+			pvc.Failed <- status
+		} else {
+			pvc.Status <- status
+		}
+	case tracker.ResourceDeleted:
+		if status.IsFailed {
+			pvc.State = tracker.ResourceFailed
+			pvc.Failed <- status
+		} else if status.IsReady {
+			pvc.State = tracker.ResourceReady
+			pvc.Ready <- status
+		} else {
+			pvc.State = tracker.ResourceAdded
+			pvc.Added <- status
+		}
+	}
+}
+
+// runEventsInformer watch for PersistentVolumeClaim events
+func (pvc *Tracker) runEventsInformer(object *corev1.PersistentVolumeClaim) {
+	eventInformer := event.NewEventInformer(&pvc.Tracker, object)
+	eventInformer.WithChannels(pvc.EventMsg, pvc.objectFailed, pvc.errors)
+	eventInformer.Run()
+}
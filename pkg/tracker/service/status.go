@@ -0,0 +1,102 @@
+package service
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/flant/kubedog/pkg/utils"
+)
+
+// Requirements define when the Service is ready
+type Requirements struct {
+	// MinReadyEndpoints is the minimal number of ready addresses behind the Service.
+	// By default 1 for the Services with selector and 0 for other Services.
+	MinReadyEndpoints *int
+	// UseEndpointSlices enables counting of addresses using EndpointSlices instead of Endpoints
+	UseEndpointSlices bool
+}
+
+func (r Requirements) minReadyEndpoints(object *corev1.Service) int {
+	if r.MinReadyEndpoints != nil {
+		return *r.MinReadyEndpoints
+	}
+
+	if object.Spec.Type == corev1.ServiceTypeExternalName || len(object.Spec.Selector) == 0 {
+		return 0
+	}
+	return 1
+}
+
+// EndpointsInfo contains addresses behind the Service
+type EndpointsInfo struct {
+	ReadyAddresses    []string
+	NotReadyAddresses []string
+}
+
+type ServiceStatus struct {
+	corev1.ServiceStatus
+
+	StatusGeneration uint64
+
+	Type              corev1.ServiceType
+	ClusterIP         string
+	ExternalAddresses []string
+	Age               string
+
+	ReadyEndpoints    int
+	NotReadyEndpoints int
+	MinReadyEndpoints int
+
+	IsLoadBalancerReady bool
+
+	WaitingForMessages []string
+
+	IsReady      bool
+	IsFailed     bool
+	FailedReason string
+}
+
+func NewServiceStatus(object *corev1.Service, statusGeneration uint64, endpoints EndpointsInfo, requirements Requirements, isTrackerFailed bool, trackerFailedReason string) ServiceStatus {
+	res := ServiceStatus{
+		ServiceStatus:     object.Status,
+		StatusGeneration:  statusGeneration,
+		Type:              object.Spec.Type,
+		ClusterIP:         object.Spec.ClusterIP,
+		Age:               utils.TranslateTimestampSince(object.CreationTimestamp),
+		ReadyEndpoints:    len(endpoints.ReadyAddresses),
+		NotReadyEndpoints: len(endpoints.NotReadyAddresses),
+		MinReadyEndpoints: requirements.minReadyEndpoints(object),
+	}
+
+	for _, ingress := range object.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			res.ExternalAddresses = append(res.ExternalAddresses, ingress.IP)
+		} else if ingress.Hostname != "" {
+			res.ExternalAddresses = append(res.ExternalAddresses, ingress.Hostname)
+		}
+	}
+	if object.Spec.Type == corev1.ServiceTypeExternalName {
+		res.ExternalAddresses = append(res.ExternalAddresses, object.Spec.ExternalName)
+	}
+
+	res.IsLoadBalancerReady = true
+	if object.Spec.Type == corev1.ServiceTypeLoadBalancer && len(object.Status.LoadBalancer.Ingress) == 0 {
+		res.IsLoadBalancerReady = false
+		res.WaitingForMessages = append(res.WaitingForMessages, "load balancer ingress ip or hostname")
+	}
+
+	isEndpointsReady := res.ReadyEndpoints >= res.MinReadyEndpoints
+	if !isEndpointsReady {
+		res.WaitingForMessages = append(res.WaitingForMessages, fmt.Sprintf("ready endpoints %d->%d", res.ReadyEndpoints, res.MinReadyEndpoints))
+	}
+
+	res.IsReady = res.IsLoadBalancerReady && isEndpointsReady
+
+	if !res.IsReady {
+		res.IsFailed = isTrackerFailed
+		res.FailedReason = trackerFailedReason
+	}
+
+	return res
+}
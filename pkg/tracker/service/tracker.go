@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1alpha1 "k8s.io/api/discovery/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/debug"
+	"github.com/flant/kubedog/pkg/tracker/event"
+)
+
+// Tracker watches the Service along with Endpoints (or EndpointSlices) of the Service
+type Tracker struct {
+	tracker.Tracker
+
+	Requirements Requirements
+
+	Added  chan ServiceStatus
+	Ready  chan ServiceStatus
+	Failed chan ServiceStatus
+	Status chan ServiceStatus
+
+	EventMsg chan string
+
+	State tracker.TrackerState
+
+	lastObject   *corev1.Service
+	endpoints    EndpointsInfo
+	failedReason string
+
+	objectAdded      chan *corev1.Service
+	objectModified   chan *corev1.Service
+	objectDeleted    chan *corev1.Service
+	objectFailed     chan string
+	endpointsChanged chan EndpointsInfo
+	errors           chan error
+}
+
+func NewTracker(ctx context.Context, name, namespace string, requirements Requirements, kube kubernetes.Interface, opts tracker.Options) *Tracker {
+	return &Tracker{
+		Tracker: tracker.Tracker{
+			Kube:             kube,
+			Namespace:        namespace,
+			FullResourceName: fmt.Sprintf("svc/%s", name),
+			ResourceName:     name,
+			Context:          ctx,
+			LogsFromTime:     opts.LogsFromTime,
+		},
+
+		Requirements: requirements,
+
+		Added:  make(chan ServiceStatus, 1),
+		Ready:  make(chan ServiceStatus, 0),
+		Failed: make(chan ServiceStatus, 0),
+		Status: make(chan ServiceStatus, 100),
+
+		EventMsg: make(chan string, 1),
+
+		State: tracker.Initial,
+
+		objectAdded:      make(chan *corev1.Service, 0),
+		objectModified:   make(chan *corev1.Service, 0),
+		objectDeleted:    make(chan *corev1.Service, 0),
+		objectFailed:     make(chan string, 1),
+		endpointsChanged: make(chan EndpointsInfo, 0),
+		errors:           make(chan error, 0),
+	}
+}
+
+func (svc *Tracker) Track() error {
+	svc.runInformer()
+
+	if svc.Requirements.UseEndpointSlices {
+		svc.runEndpointSlicesInformer()
+	} else {
+		svc.runEndpointsInformer()
+	}
+
+	for {
+		select {
+		case object := <-svc.objectAdded:
+			svc.handleServiceState(object)
+
+		case object := <-svc.objectModified:
+			svc.handleServiceState(object)
+
+		case endpoints := <-svc.endpointsChanged:
+			svc.endpoints = endpoints
+			if svc.lastObject != nil {
+				svc.handleServiceState(svc.lastObject)
+			}
+
+		case reason := <-svc.objectFailed:
+			svc.State = tracker.ResourceFailed
+			svc.failedReason = reason
+
+			var status ServiceStatus
+			if svc.lastObject != nil {
+				svc.StatusGeneration++
+				status = NewServiceStatus(svc.lastObject, svc.StatusGeneration, svc.endpoints, svc.Requirements, true, svc.failedReason)
+			} else {
+				status = ServiceStatus{IsFailed: true, FailedReason: reason}
+			}
+			svc.Failed <- status
+
+		case <-svc.objectDeleted:
+			svc.State = tracker.ResourceDeleted
+			svc.lastObject = nil
+			svc.Status <- ServiceStatus{}
+
+		case <-svc.Context.Done():
+			if svc.Context.Err() == context.Canceled {
+				return nil
+			}
+			return svc.Context.Err()
+		case err := <-svc.errors:
+			return err
+		}
+	}
+}
+
+func (svc *Tracker) runInformer() {
+	tweakListOptions := func(options metav1.ListOptions) metav1.ListOptions {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", svc.ResourceName).String()
+		return options
+	}
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return svc.Kube.CoreV1().Services(svc.Namespace).List(tweakListOptions(options))
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return svc.Kube.CoreV1().Services(svc.Namespace).Watch(tweakListOptions(options))
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(svc.Context, lw, &corev1.Service{}, nil, func(e watch.Event) (bool, error) {
+			if debug.Debug() {
+				fmt.Printf("Service `%s` informer event: %#v\n", svc.ResourceName, e.Type)
+			}
+
+			var object *corev1.Service
+
+			if e.Type != watch.Error {
+				var ok bool
+				object, ok = e.Object.(*corev1.Service)
+				if !ok {
+					return true, fmt.Errorf("expected %s to be a *corev1.Service, got %T", svc.ResourceName, e.Object)
+				}
+			}
+
+			if e.Type == watch.Added {
+				svc.objectAdded <- object
+			} else if e.Type == watch.Modified {
+				svc.objectModified <- object
+			} else if e.Type == watch.Deleted {
+				svc.objectDeleted <- object
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			svc.errors <- fmt.Errorf("service informer error: %s", err)
+		}
+
+		if debug.Debug() {
+			fmt.Printf("Service `%s` informer done\n", svc.ResourceName)
+		}
+	}()
+}
+
+// runEndpointsInformer watch for Endpoints object of the Service
+func (svc *Tracker) runEndpointsInformer() {
+	tweakListOptions := func(options metav1.ListOptions) metav1.ListOptions {
+		options.FieldSelector = fields.OneTermEqualSelector("metadata.name", svc.ResourceName).String()
+		return options
+	}
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return svc.Kube.CoreV1().Endpoints(svc.Namespace).List(tweakListOptions(options))
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return svc.Kube.CoreV1().Endpoints(svc.Namespace).Watch(tweakListOptions(options))
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(svc.Context, lw, &corev1.Endpoints{}, nil, func(e watch.Event) (bool, error) {
+			if debug.Debug() {
+				fmt.Printf("Service `%s` endpoints informer event: %#v\n", svc.ResourceName, e.Type)
+			}
+
+			if e.Type == watch.Error {
+				return true, fmt.Errorf("endpoints watch error: %v", e.Object)
+			}
+
+			object, ok := e.Object.(*corev1.Endpoints)
+			if !ok {
+				return true, fmt.Errorf("expected %s endpoints to be a *corev1.Endpoints, got %T", svc.ResourceName, e.Object)
+			}
+
+			if e.Type == watch.Deleted {
+				svc.endpointsChanged <- EndpointsInfo{}
+			} else {
+				svc.endpointsChanged <- EndpointsInfoFromEndpoints(object)
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			svc.errors <- fmt.Errorf("service endpoints informer error: %s", err)
+		}
+	}()
+}
+
+// runEndpointSlicesInformer watch for EndpointSlices of the Service
+func (svc *Tracker) runEndpointSlicesInformer() {
+	tweakListOptions := func(options metav1.ListOptions) metav1.ListOptions {
+		options.LabelSelector = labels.SelectorFromSet(labels.Set{discoveryv1alpha1.LabelServiceName: svc.ResourceName}).String()
+		return options
+	}
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return svc.Kube.DiscoveryV1alpha1().EndpointSlices(svc.Namespace).List(tweakListOptions(options))
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return svc.Kube.DiscoveryV1alpha1().EndpointSlices(svc.Namespace).Watch(tweakListOptions(options))
+		},
+	}
+
+	go func() {
+		slices := make(map[string]EndpointsInfo)
+
+		_, err := watchtools.UntilWithSync(svc.Context, lw, &discoveryv1alpha1.EndpointSlice{}, nil, func(e watch.Event) (bool, error) {
+			if debug.Debug() {
+				fmt.Printf("Service `%s` endpoint slices informer event: %#v\n", svc.ResourceName, e.Type)
+			}
+
+			if e.Type == watch.Error {
+				return true, fmt.Errorf("endpoint slices watch error: %v", e.Object)
+			}
+
+			object, ok := e.Object.(*discoveryv1alpha1.EndpointSlice)
+			if !ok {
+				return true, fmt.Errorf("expected %s endpoint slice to be a *discoveryv1alpha1.EndpointSlice, got %T", svc.ResourceName, e.Object)
+			}
+
+			if e.Type == watch.Deleted {
+				delete(slices, object.Name)
+			} else {
+				slices[object.Name] = endpointsInfoFromEndpointSlice(object)
+			}
+
+			res := EndpointsInfo{}
+			for _, info := range slices {
+				res.ReadyAddresses = append(res.ReadyAddresses, info.ReadyAddresses...)
+				res.NotReadyAddresses = append(res.NotReadyAddresses, info.NotReadyAddresses...)
+			}
+			svc.endpointsChanged <- res
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			svc.errors <- fmt.Errorf("service endpoint slices informer error: %s", err)
+		}
+	}()
+}
+
+// EndpointsInfoFromEndpoints returns unique ready and not ready addresses of the Endpoints
+func EndpointsInfoFromEndpoints(object *corev1.Endpoints) EndpointsInfo {
+	res := EndpointsInfo{}
+	ready := make(map[string]bool)
+	notReady := make(map[string]bool)
+
+	// Subsets with different ports contain the same addresses
+	for _, subset := range object.Subsets {
+		for _, address := range subset.Addresses {
+			if !ready[address.IP] {
+				ready[address.IP] = true
+				res.ReadyAddresses = append(res.ReadyAddresses, address.IP)
+			}
+		}
+		for _, address := range subset.NotReadyAddresses {
+			if !notReady[address.IP] {
+				notReady[address.IP] = true
+				res.NotReadyAddresses = append(res.NotReadyAddresses, address.IP)
+			}
+		}
+	}
+
+	return res
+}
+
+func endpointsInfoFromEndpointSlice(object *discoveryv1alpha1.EndpointSlice) EndpointsInfo {
+	res := EndpointsInfo{}
+
+	for _, endpoint := range object.Endpoints {
+		if len(endpoint.Addresses) == 0 {
+			continue
+		}
+
+		// Ready condition is nil when the state is unknown, such endpoints should be interpreted as ready
+		if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+			res.ReadyAddresses = append(res.ReadyAddresses, endpoint.Addresses[0])
+		} else {
+			res.NotReadyAddresses = append(res.NotReadyAddresses, endpoint.Addresses[0])
+		}
+	}
+
+	return res
+}
+
+func (svc *Tracker) handleServiceState(object *corev1.Service) {
+	svc.lastObject = object
+	svc.StatusGeneration++
+
+	status := NewServiceStatus(object, svc.StatusGeneration, svc.endpoints, svc.Requirements, svc.State == tracker.ResourceFailed, svc.failedReason)
+
+	switch svc.State {
+	case tracker.Initial:
+		svc.runEventsInformer(object)
+
+		if status.IsFailed {
+			svc.State = tracker.ResourceFailed
+			svc.Failed <- status
+		} else if status.IsReady {
+			svc.State = tracker.ResourceReady
+			svc.Ready <- status
+		} else {
+			svc.State = tracker.ResourceAdded
+			svc.Added <- status
+		}
+	case tracker.ResourceAdded, tracker.ResourceFailed:
+		if status.IsFailed {
+			svc.State = tracker.ResourceFailed
+			svc.Status <- status
+		} else if status.IsReady {
+			svc.State = tracker.ResourceReady
+			svc.Ready <- status
+		} else {
+			svc.Status <- status
+		}
+	case tracker.ResourceReady:
+		svc.Status <- status
+	case tracker.ResourceDeleted:
+		if status.IsFailed {
+			svc.State = tracker.ResourceFailed
+			svc.Failed <- status
+		} else if status.IsReady {
+			svc.State = tracker.ResourceReady
+			svc.Ready <- status
+		} else {
+			svc.State = tracker.ResourceAdded
+			svc.Added <- status
+		}
+	}
+}
+
+// runEventsInformer watch for Service events
+func (svc *Tracker) runEventsInformer(object *corev1.Service) {
+	eventInformer := event.NewEventInformer(&svc.Tracker, object)
+	eventInformer.WithChannels(svc.EventMsg, svc.objectFailed, svc.errors)
+	eventInformer.Run()
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/flant/kubedog/pkg/tracker/indicators"
 	"github.com/flant/kubedog/pkg/tracker/pod"
+	"github.com/flant/kubedog/pkg/tracker/pvc"
 
 	appsv1 "k8s.io/api/apps/v1"
 )
@@ -27,13 +28,17 @@ type StatefulSetStatus struct {
 
 	Pods         map[string]pod.PodStatus
 	NewPodsNames []string
+
+	// PersistentVolumeClaims created from the volumeClaimTemplates, map by PVC name
+	PVCs map[string]pvc.PVCStatus
 }
 
-func NewStatefulSetStatus(object *appsv1.StatefulSet, statusGeneration uint64, isFailed bool, failedReason string, warningMessages []string, podsStatuses map[string]pod.PodStatus, newPodsNames []string) StatefulSetStatus {
+func NewStatefulSetStatus(object *appsv1.StatefulSet, statusGeneration uint64, isFailed bool, failedReason string, warningMessages []string, podsStatuses map[string]pod.PodStatus, newPodsNames []string, pvcStatuses map[string]pvc.PVCStatus) StatefulSetStatus {
 	res := StatefulSetStatus{
 		StatusGeneration:  statusGeneration,
 		StatefulSetStatus: object.Status,
 		Pods:              make(map[string]pod.PodStatus),
+		PVCs:              make(map[string]pvc.PVCStatus),
 		NewPodsNames:      newPodsNames,
 		IsReady:           true,
 		IsFailed:          isFailed,
@@ -41,6 +46,10 @@ func NewStatefulSetStatus(object *appsv1.StatefulSet, statusGeneration uint64, i
 		WarningMessages:   warningMessages,
 	}
 
+	for k, v := range pvcStatuses {
+		res.PVCs[k] = v
+	}
+
 	// TODO: share common code from deploy, ds and sts
 processingPodsStatuses:
 	for k, v := range podsStatuses {
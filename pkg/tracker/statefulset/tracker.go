@@ -3,6 +3,7 @@ package statefulset
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -19,6 +20,7 @@ import (
 	"github.com/flant/kubedog/pkg/tracker/debug"
 	"github.com/flant/kubedog/pkg/tracker/event"
 	"github.com/flant/kubedog/pkg/tracker/pod"
+	"github.com/flant/kubedog/pkg/tracker/pvc"
 	"github.com/flant/kubedog/pkg/tracker/replicaset"
 	"github.com/flant/kubedog/pkg/utils"
 )
@@ -43,6 +45,7 @@ type Tracker struct {
 	failedReason string
 	podStatuses  map[string]pod.PodStatus
 	podRevisions map[string]string
+	pvcStatuses  map[string]pvc.PVCStatus
 
 	TrackedPodsNames []string
 
@@ -67,6 +70,8 @@ type Tracker struct {
 	podLogChunksRelay       chan map[string]*pod.ContainerLogChunk
 	podContainerErrorsRelay chan map[string]pod.ContainerErrorReport
 	donePodsRelay           chan map[string]pod.PodStatus
+	pvcRelay                chan *corev1.PersistentVolumeClaim
+	pvcDeletedRelay         chan string
 }
 
 func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Interface, opts tracker.Options) *Tracker {
@@ -95,6 +100,7 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 
 		podStatuses:  make(map[string]pod.PodStatus),
 		podRevisions: make(map[string]string),
+		pvcStatuses:  make(map[string]pvc.PVCStatus),
 
 		resourceAdded:    make(chan *appsv1.StatefulSet, 1),
 		resourceModified: make(chan *appsv1.StatefulSet, 1),
@@ -107,6 +113,8 @@ func NewTracker(ctx context.Context, name, namespace string, kube kubernetes.Int
 		podLogChunksRelay:       make(chan map[string]*pod.ContainerLogChunk, 10),
 		podContainerErrorsRelay: make(chan map[string]pod.ContainerErrorReport, 10),
 		donePodsRelay:           make(chan map[string]pod.PodStatus, 10),
+		pvcRelay:                make(chan *corev1.PersistentVolumeClaim, 10),
+		pvcDeletedRelay:         make(chan string, 10),
 	}
 }
 
@@ -138,6 +146,7 @@ func (d *Tracker) Track() (err error) {
 			d.TrackedPodsNames = nil
 			d.podStatuses = make(map[string]pod.PodStatus)
 			d.podRevisions = make(map[string]string)
+			d.pvcStatuses = make(map[string]pvc.PVCStatus)
 			d.Status <- StatefulSetStatus{}
 
 		case reason := <-d.resourceFailed:
@@ -156,7 +165,7 @@ func (d *Tracker) Track() (err error) {
 				var status StatefulSetStatus
 				if d.lastObject != nil {
 					d.StatusGeneration++
-					status = NewStatefulSetStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, nil, d.podStatuses, d.getNewPodsNames())
+					status = NewStatefulSetStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, nil, d.podStatuses, d.getNewPodsNames(), d.pvcStatuses)
 				} else {
 					status = StatefulSetStatus{IsFailed: true, FailedReason: reason}
 				}
@@ -168,7 +177,7 @@ func (d *Tracker) Track() (err error) {
 
 			if d.lastObject != nil {
 				d.StatusGeneration++
-				status := NewStatefulSetStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, nil, d.podStatuses, d.getNewPodsNames())
+				status := NewStatefulSetStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, nil, d.podStatuses, d.getNewPodsNames(), d.pvcStatuses)
 
 				d.AddedPod <- PodAddedReport{
 					ReplicaSetPod: replicaset.ReplicaSetPod{
@@ -237,7 +246,7 @@ func (d *Tracker) Track() (err error) {
 			}
 			if d.lastObject != nil {
 				d.StatusGeneration++
-				status := NewStatefulSetStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, nil, d.podStatuses, d.getNewPodsNames())
+				status := NewStatefulSetStatus(d.lastObject, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, nil, d.podStatuses, d.getNewPodsNames(), d.pvcStatuses)
 
 				for podName, containerError := range podContainerErrors {
 					d.PodError <- PodErrorReport{
@@ -254,6 +263,22 @@ func (d *Tracker) Track() (err error) {
 
 			}
 
+		case object := <-d.pvcRelay:
+			d.pvcStatuses[object.Name] = pvc.NewPVCStatus(object, d.StatusGeneration, false, "")
+			if d.lastObject != nil {
+				if err := d.handleStatefulSetState(d.lastObject, nil); err != nil {
+					return err
+				}
+			}
+
+		case pvcName := <-d.pvcDeletedRelay:
+			delete(d.pvcStatuses, pvcName)
+			if d.lastObject != nil {
+				if err := d.handleStatefulSetState(d.lastObject, nil); err != nil {
+					return err
+				}
+			}
+
 		case <-d.Context.Done():
 			if d.Context.Err() == context.Canceled {
 				return nil
@@ -335,6 +360,75 @@ func (d *Tracker) runPodsInformer(object *appsv1.StatefulSet) {
 	podsInformer.Run()
 }
 
+// runPVCsInformer watch for PersistentVolumeClaims created from the StatefulSet volumeClaimTemplates
+func (d *Tracker) runPVCsInformer(object *appsv1.StatefulSet) {
+	if len(object.Spec.VolumeClaimTemplates) == 0 {
+		return
+	}
+
+	var templatesNames []string
+	for _, template := range object.Spec.VolumeClaimTemplates {
+		templatesNames = append(templatesNames, template.Name)
+	}
+
+	lw := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return d.Kube.CoreV1().PersistentVolumeClaims(d.Namespace).List(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return d.Kube.CoreV1().PersistentVolumeClaims(d.Namespace).Watch(options)
+		},
+	}
+
+	go func() {
+		_, err := watchtools.UntilWithSync(d.Context, lw, &corev1.PersistentVolumeClaim{}, nil, func(e watch.Event) (bool, error) {
+			if e.Type == watch.Error {
+				return true, fmt.Errorf("pvc watch error: %v", e.Object)
+			}
+
+			pvcObject, ok := e.Object.(*corev1.PersistentVolumeClaim)
+			if !ok {
+				return true, fmt.Errorf("expected *corev1.PersistentVolumeClaim, got %T", e.Object)
+			}
+
+			if !isVolumeClaimTemplatePVC(pvcObject.Name, d.ResourceName, templatesNames) {
+				return false, nil
+			}
+
+			if debug.Debug() {
+				fmt.Printf("    sts/%s pvc/%s event: %#v\n", d.ResourceName, pvcObject.Name, e.Type)
+			}
+
+			if e.Type == watch.Deleted {
+				d.pvcDeletedRelay <- pvcObject.Name
+			} else {
+				d.pvcRelay <- pvcObject
+			}
+
+			return false, nil
+		})
+
+		if err := tracker.AdaptInformerError(err); err != nil {
+			d.errors <- fmt.Errorf("sts/%s pvc informer error: %s", d.ResourceName, err)
+		}
+	}()
+}
+
+// isVolumeClaimTemplatePVC checks that the PVC name is TEMPLATE-STATEFULSET-ORDINAL
+func isVolumeClaimTemplatePVC(pvcName, statefulSetName string, templatesNames []string) bool {
+	for _, templateName := range templatesNames {
+		prefix := fmt.Sprintf("%s-%s-", templateName, statefulSetName)
+		if !strings.HasPrefix(pvcName, prefix) {
+			continue
+		}
+
+		if _, err := strconv.Atoi(strings.TrimPrefix(pvcName, prefix)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (d *Tracker) runPodTracker(podName string) error {
 	errorChan := make(chan error, 0)
 	doneChan := make(chan struct{}, 0)
@@ -402,11 +496,12 @@ func (d *Tracker) handleStatefulSetState(object *appsv1.StatefulSet, warningMess
 	d.lastObject = object
 	d.StatusGeneration++
 
-	status := NewStatefulSetStatus(object, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, warningMessages, d.podStatuses, d.getNewPodsNames())
+	status := NewStatefulSetStatus(object, d.StatusGeneration, (d.State == tracker.ResourceFailed), d.failedReason, warningMessages, d.podStatuses, d.getNewPodsNames(), d.pvcStatuses)
 
 	switch d.State {
 	case tracker.Initial:
 		d.runPodsInformer(object)
+		d.runPVCsInformer(object)
 		d.runEventsInformer(object)
 
 		if status.IsFailed {
@@ -429,6 +524,13 @@ func (d *Tracker) handleStatefulSetState(object *appsv1.StatefulSet, warningMess
 		} else {
 			d.Status <- status
 		}
+	case tracker.ResourceReady:
+		if status.IsFailed {
+			d.State = tracker.ResourceFailed
+			d.Failed <- status
+		} else {
+			d.Status <- status
+		}
 	case tracker.ResourceSucceeded:
 		d.Status <- status
 	case tracker.ResourceDeleted:
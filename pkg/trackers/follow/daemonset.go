@@ -44,8 +44,8 @@ func TrackDaemonSet(name, namespace string, kube kubernetes.Interface, opts trac
 		return nil
 	})
 	feed.OnPodLogChunk(func(chunk *replicaset.ReplicaSetPodLogChunk) error {
-		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.ContainerName)
-		display.OutputLogLines(header, chunk.LogLines)
+		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.Label())
+		display.OutputLogLines(header, chunk.DisplayLogLines())
 		return nil
 	})
 
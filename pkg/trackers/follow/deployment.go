@@ -62,11 +62,11 @@ func TrackDeployment(name, namespace string, kube kubernetes.Interface, opts tra
 	feed.OnPodLogChunk(func(chunk *replicaset.ReplicaSetPodLogChunk) error {
 		header := ""
 		if chunk.ReplicaSet.IsNew {
-			header = fmt.Sprintf("deploy/%s rs/%s(new) po/%s %s", name, chunk.ReplicaSet.Name, chunk.PodName, chunk.ContainerName)
+			header = fmt.Sprintf("deploy/%s rs/%s(new) po/%s %s", name, chunk.ReplicaSet.Name, chunk.PodName, chunk.Label())
 		} else {
-			header = fmt.Sprintf("deploy/%s rs/%s po/%s %s", name, chunk.ReplicaSet.Name, chunk.PodName, chunk.ContainerName)
+			header = fmt.Sprintf("deploy/%s rs/%s po/%s %s", name, chunk.ReplicaSet.Name, chunk.PodName, chunk.Label())
 		}
-		display.OutputLogLines(header, chunk.LogLines)
+		display.OutputLogLines(header, chunk.DisplayLogLines())
 		return nil
 	})
 
@@ -40,8 +40,8 @@ func TrackJob(name, namespace string, kube kubernetes.Interface, opts tracker.Op
 		return nil
 	})
 	feed.OnPodLogChunk(func(chunk *pod.PodLogChunk) error {
-		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.ContainerName)
-		display.OutputLogLines(header, chunk.LogLines)
+		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.Label())
+		display.OutputLogLines(header, chunk.DisplayLogLines())
 		return nil
 	})
 
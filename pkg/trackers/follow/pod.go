@@ -39,8 +39,8 @@ func TrackPod(name, namespace string, kube kubernetes.Interface, opts tracker.Op
 		return nil
 	})
 	feed.OnContainerLogChunk(func(chunk *pod.ContainerLogChunk) error {
-		header := fmt.Sprintf("po/%s %s", name, chunk.ContainerName)
-		display.OutputLogLines(header, chunk.LogLines)
+		header := fmt.Sprintf("po/%s %s", name, chunk.Label())
+		display.OutputLogLines(header, chunk.DisplayLogLines())
 		return nil
 	})
 
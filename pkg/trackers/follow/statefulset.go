@@ -44,8 +44,8 @@ func TrackStatefulSet(name, namespace string, kube kubernetes.Interface, opts tr
 		return nil
 	})
 	feed.OnPodLogChunk(func(chunk *replicaset.ReplicaSetPodLogChunk) error {
-		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.ContainerName)
-		display.OutputLogLines(header, chunk.LogLines)
+		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.Label())
+		display.OutputLogLines(header, chunk.DisplayLogLines())
 		return nil
 	})
 
@@ -46,8 +46,8 @@ func TrackDaemonSetTillReady(name, namespace string, kube kubernetes.Interface,
 		return tracker.ResourceErrorf("ds/%s po/%s %s failed: %s", name, podError.PodName, podError.ContainerName, podError.Message)
 	})
 	feed.OnPodLogChunk(func(chunk *replicaset.ReplicaSetPodLogChunk) error {
-		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.ContainerName)
-		display.OutputLogLines(header, chunk.LogLines)
+		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.Label())
+		display.OutputLogLines(header, chunk.DisplayLogLines())
 		return nil
 	})
 
@@ -60,8 +60,8 @@ func TrackDeploymentTillReady(name, namespace string, kube kubernetes.Interface,
 		if !chunk.ReplicaSet.IsNew {
 			return nil
 		}
-		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.ContainerName)
-		display.OutputLogLines(header, chunk.LogLines)
+		header := fmt.Sprintf("po/%s %s", chunk.PodName, chunk.Label())
+		display.OutputLogLines(header, chunk.DisplayLogLines())
 		return nil
 	})
 
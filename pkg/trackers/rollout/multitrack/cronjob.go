@@ -0,0 +1,211 @@
+package multitrack
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/flant/kubedog/pkg/tracker/cronjob"
+)
+
+func (mt *multitracker) TrackCronJob(kube kubernetes.Interface, spec MultitrackSpec, opts MultitrackOptions) error {
+	feed := cronjob.NewFeed()
+
+	feed.OnAdded(func(isReady bool) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.CronJobsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.cronJobAdded(spec, feed, isReady)
+	})
+	feed.OnReady(func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.CronJobsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.cronJobReady(spec, feed)
+	})
+	feed.OnFailed(func(reason string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.CronJobsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.cronJobFailed(spec, feed, reason)
+	})
+	feed.OnEventMsg(func(msg string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.CronJobsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.cronJobEventMsg(spec, feed, msg)
+	})
+	feed.OnAddedJob(func(jobName string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.CronJobsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.cronJobAddedJob(spec, feed, jobName)
+	})
+	feed.OnJobSucceeded(func(jobName string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.CronJobsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.cronJobJobSucceeded(spec, feed, jobName)
+	})
+	feed.OnJobFailed(func(jobName, reason string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.CronJobsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.cronJobJobFailed(spec, feed, jobName, reason)
+	})
+	feed.OnAddedPod(func(jobName, podName string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.CronJobsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.cronJobAddedPod(spec, feed, jobName, podName)
+	})
+	feed.OnPodLogChunk(func(chunk *cronjob.JobPodLogChunk) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.CronJobsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.cronJobPodLogChunk(spec, feed, chunk)
+	})
+	feed.OnPodError(func(podError cronjob.JobPodError) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.CronJobsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.cronJobPodError(spec, feed, podError)
+	})
+	feed.OnStatus(func(status cronjob.CronJobStatus) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.CronJobsStatuses[resourceKey(spec)] = status
+
+		if !status.IsFailed && !hasCronJobPodsErrors(status) {
+			mt.resetResourceFailure(mt.TrackingCronJobs, spec)
+		}
+
+		return nil
+	})
+
+	return feed.Track(spec.ResourceName, spec.Namespace, kube, opts.Options)
+}
+
+func hasCronJobPodsErrors(status cronjob.CronJobStatus) bool {
+	for _, jobStatus := range status.Jobs {
+		if hasPodsErrors(jobStatus.Pods, podsNames(jobStatus.Pods)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (mt *multitracker) cronJobAdded(spec MultitrackSpec, feed cronjob.Feed, isReady bool) error {
+	mt.handleResourceAdded(mt.TrackingCronJobs, spec)
+
+	if isReady && !spec.WaitForNextRun {
+		mt.displayResourceTrackerMessageF("cronjob", spec, "appears to be READY")
+		mt.emitResourceEvent("cronjob", spec, Event{Type: ResourceReadyEvent})
+
+		return mt.handleResourceReadyCondition(mt.TrackingCronJobs, spec)
+	}
+
+	mt.displayResourceTrackerMessageF("cronjob", spec, "added")
+	mt.emitResourceEvent("cronjob", spec, Event{Type: ResourceAddedEvent})
+
+	return nil
+}
+
+func (mt *multitracker) cronJobReady(spec MultitrackSpec, feed cronjob.Feed) error {
+	if spec.WaitForNextRun {
+		mt.displayResourceTrackerMessageF("cronjob", spec, "resumed")
+
+		return nil
+	}
+
+	mt.displayResourceTrackerMessageF("cronjob", spec, "become READY")
+	mt.emitResourceEvent("cronjob", spec, Event{Type: ResourceReadyEvent})
+
+	return mt.handleResourceReadyCondition(mt.TrackingCronJobs, spec)
+}
+
+func (mt *multitracker) cronJobFailed(spec MultitrackSpec, feed cronjob.Feed, reason string) error {
+	mt.displayResourceErrorF("cronjob", spec, "%s", reason)
+	mt.emitResourceEvent("cronjob", spec, Event{Type: ResourceFailedEvent, Message: reason})
+
+	return mt.handleResourceFailure(mt.TrackingCronJobs, "cronjob", spec, reason)
+}
+
+func (mt *multitracker) cronJobEventMsg(spec MultitrackSpec, feed cronjob.Feed, msg string) error {
+	mt.displayResourceEventF("cronjob", spec, "%s", msg)
+	return nil
+}
+
+func (mt *multitracker) cronJobAddedJob(spec MultitrackSpec, feed cronjob.Feed, jobName string) error {
+	mt.displayResourceTrackerMessageF("cronjob", spec, "job/%s added", jobName)
+	return nil
+}
+
+func (mt *multitracker) cronJobJobSucceeded(spec MultitrackSpec, feed cronjob.Feed, jobName string) error {
+	mt.displayResourceTrackerMessageF("cronjob", spec, "job/%s succeeded", jobName)
+
+	if !spec.WaitForNextRun {
+		return nil
+	}
+
+	mt.emitResourceEvent("cronjob", spec, Event{Type: ResourceReadyEvent})
+
+	return mt.handleResourceReadyCondition(mt.TrackingCronJobs, spec)
+}
+
+func (mt *multitracker) cronJobJobFailed(spec MultitrackSpec, feed cronjob.Feed, jobName, reason string) error {
+	reason = fmt.Sprintf("job/%s: %s", jobName, reason)
+
+	mt.displayResourceErrorF("cronjob", spec, "%s", reason)
+	mt.emitResourceEvent("cronjob", spec, Event{Type: ResourceFailedEvent, Message: reason})
+
+	return mt.handleResourceFailure(mt.TrackingCronJobs, "cronjob", spec, reason)
+}
+
+func (mt *multitracker) cronJobAddedPod(spec MultitrackSpec, feed cronjob.Feed, jobName, podName string) error {
+	mt.displayResourceTrackerMessageF("cronjob", spec, "job/%s po/%s added", jobName, podName)
+	mt.emitResourceEvent("cronjob", spec, Event{Type: PodAddedEvent, Pod: podName})
+	return nil
+}
+
+func (mt *multitracker) cronJobPodLogChunk(spec MultitrackSpec, feed cronjob.Feed, chunk *cronjob.JobPodLogChunk) error {
+	status := mt.CronJobsStatuses[resourceKey(spec)]
+	if !isPodLogsVisible(spec, status.Jobs[chunk.JobName].Pods, chunk.PodName) {
+		return nil
+	}
+
+	header := fmt.Sprintf("job/%s %s", chunk.JobName, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk))
+	mt.displayResourceLogChunk("cronjob", spec, chunk.PodName, header, chunk.ContainerLogChunk)
+	return nil
+}
+
+func (mt *multitracker) cronJobPodError(spec MultitrackSpec, feed cronjob.Feed, podError cronjob.JobPodError) error {
+	reason := fmt.Sprintf("job/%s po/%s container/%s: %s", podError.JobName, podError.PodName, podError.ContainerName, podError.Message)
+	reason = withContainerFailureHint(reason, mt.CronJobsStatuses[resourceKey(spec)].Jobs[podError.JobName].Pods, podError.PodName, podError.ContainerName)
+
+	mt.displayResourceErrorF("cronjob", spec, "%s", reason)
+	mt.emitResourceEvent("cronjob", spec, Event{Type: ContainerErrorEvent, Pod: podError.PodName, Container: podError.ContainerName, Message: podError.Message})
+
+	return mt.handleResourceFailure(mt.TrackingCronJobs, "cronjob", spec, reason)
+}
@@ -15,7 +15,7 @@ func (mt *multitracker) TrackDaemonSet(kube kubernetes.Interface, spec Multitrac
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DaemonSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DaemonSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.daemonsetAdded(spec, feed, isReady)
 	})
@@ -23,7 +23,7 @@ func (mt *multitracker) TrackDaemonSet(kube kubernetes.Interface, spec Multitrac
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DaemonSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DaemonSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.daemonsetReady(spec, feed)
 	})
@@ -31,7 +31,7 @@ func (mt *multitracker) TrackDaemonSet(kube kubernetes.Interface, spec Multitrac
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DaemonSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DaemonSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.daemonsetFailed(spec, feed, reason)
 	})
@@ -39,7 +39,7 @@ func (mt *multitracker) TrackDaemonSet(kube kubernetes.Interface, spec Multitrac
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DaemonSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DaemonSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.daemonsetEventMsg(spec, feed, msg)
 	})
@@ -47,7 +47,7 @@ func (mt *multitracker) TrackDaemonSet(kube kubernetes.Interface, spec Multitrac
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DaemonSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DaemonSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.daemonsetAddedReplicaSet(spec, feed, rs)
 	})
@@ -55,7 +55,7 @@ func (mt *multitracker) TrackDaemonSet(kube kubernetes.Interface, spec Multitrac
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DaemonSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DaemonSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.daemonsetAddedPod(spec, feed, pod)
 	})
@@ -63,7 +63,7 @@ func (mt *multitracker) TrackDaemonSet(kube kubernetes.Interface, spec Multitrac
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DaemonSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DaemonSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.daemonsetPodError(spec, feed, podError)
 	})
@@ -71,7 +71,7 @@ func (mt *multitracker) TrackDaemonSet(kube kubernetes.Interface, spec Multitrac
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DaemonSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DaemonSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.daemonsetPodLogChunk(spec, feed, chunk)
 	})
@@ -79,34 +79,44 @@ func (mt *multitracker) TrackDaemonSet(kube kubernetes.Interface, spec Multitrac
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DaemonSetsStatuses[spec.ResourceName] = status
+		mt.DaemonSetsStatuses[resourceKey(spec)] = status
 
-		return nil
+		if !status.IsFailed && !hasPodsErrors(status.Pods, status.NewPodsNames) {
+			mt.resetResourceFailure(mt.TrackingDaemonSets, spec)
+		}
+
+		return mt.handleResourceReadyStability(mt.TrackingDaemonSets, "ds", spec, status.IsReady, podsRestartsCount(status.Pods, status.NewPodsNames))
 	})
 
 	return feed.Track(spec.ResourceName, spec.Namespace, kube, opts.Options)
 }
 
 func (mt *multitracker) daemonsetAdded(spec MultitrackSpec, feed daemonset.Feed, isReady bool) error {
+	mt.handleResourceAdded(mt.TrackingDaemonSets, spec)
+
 	if isReady {
 		mt.displayResourceTrackerMessageF("ds", spec, "appears to be READY")
+		mt.emitResourceEvent("ds", spec, Event{Type: ResourceReadyEvent})
 
 		return mt.handleResourceReadyCondition(mt.TrackingDaemonSets, spec)
 	}
 
 	mt.displayResourceTrackerMessageF("ds", spec, "added")
+	mt.emitResourceEvent("ds", spec, Event{Type: ResourceAddedEvent})
 
 	return nil
 }
 
 func (mt *multitracker) daemonsetReady(spec MultitrackSpec, feed daemonset.Feed) error {
 	mt.displayResourceTrackerMessageF("ds", spec, "become READY")
+	mt.emitResourceEvent("ds", spec, Event{Type: ResourceReadyEvent})
 
 	return mt.handleResourceReadyCondition(mt.TrackingDaemonSets, spec)
 }
 
 func (mt *multitracker) daemonsetFailed(spec MultitrackSpec, feed daemonset.Feed, reason string) error {
 	mt.displayResourceErrorF("ds", spec, "%s", reason)
+	mt.emitResourceEvent("ds", spec, Event{Type: ResourceFailedEvent, Message: reason})
 
 	return mt.handleResourceFailure(mt.TrackingDaemonSets, "ds", spec, reason)
 }
@@ -123,25 +133,26 @@ func (mt *multitracker) daemonsetAddedReplicaSet(spec MultitrackSpec, feed daemo
 
 func (mt *multitracker) daemonsetAddedPod(spec MultitrackSpec, feed daemonset.Feed, pod replicaset.ReplicaSetPod) error {
 	mt.displayResourceTrackerMessageF("ds", spec, "po/%s added", pod.Name)
+	mt.emitResourceEvent("ds", spec, Event{Type: PodAddedEvent, Pod: pod.Name})
 	return nil
 }
 
 func (mt *multitracker) daemonsetPodError(spec MultitrackSpec, feed daemonset.Feed, podError replicaset.ReplicaSetPodError) error {
 	reason := fmt.Sprintf("po/%s container/%s: %s", podError.PodName, podError.ContainerName, podError.Message)
+	reason = withContainerFailureHint(reason, mt.DaemonSetsStatuses[resourceKey(spec)].Pods, podError.PodName, podError.ContainerName)
 
 	mt.displayResourceErrorF("ds", spec, "%s", reason)
+	mt.emitResourceEvent("ds", spec, Event{Type: ContainerErrorEvent, Pod: podError.PodName, Container: podError.ContainerName, Message: podError.Message})
 
 	return mt.handleResourceFailure(mt.TrackingDaemonSets, "ds", spec, reason)
 }
 
 func (mt *multitracker) daemonsetPodLogChunk(spec MultitrackSpec, feed daemonset.Feed, chunk *replicaset.ReplicaSetPodLogChunk) error {
-	status := mt.DaemonSetsStatuses[spec.ResourceName]
-	if podStatus, hasKey := status.Pods[chunk.PodName]; hasKey {
-		if podStatus.IsReady {
-			return nil
-		}
+	status := mt.DaemonSetsStatuses[resourceKey(spec)]
+	if !isPodLogsVisible(spec, status.Pods, chunk.PodName) {
+		return nil
 	}
 
-	mt.displayResourceLogChunk("ds", spec, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk), chunk.ContainerLogChunk)
+	mt.displayResourceLogChunk("ds", spec, chunk.PodName, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk), chunk.ContainerLogChunk)
 	return nil
 }
@@ -0,0 +1,105 @@
+package multitrack
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/flant/kubedog/pkg/kube"
+	"github.com/flant/kubedog/pkg/tracker/argorollout"
+	"github.com/flant/kubedog/pkg/tracker/deletion"
+)
+
+// deletionResource returns resource of the kind to wait for deletion
+// and whether Pods of the resource should be waited too
+func (mt *multitracker) deletionResource(kind string, spec MultitrackSpec) (schema.GroupVersionResource, bool, error) {
+	if spec.Kind != "" {
+		gvr, err := kube.GroupVersionResourceByAPIVersionAndKind(mt.kube, spec.APIVersion, spec.Kind)
+		return gvr, false, err
+	}
+
+	switch kind {
+	case "deploy":
+		return schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, true, nil
+	case "sts":
+		return schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "statefulsets"}, true, nil
+	case "ds":
+		return schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "daemonsets"}, true, nil
+	case "job":
+		return schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"}, true, nil
+	case "cronjob":
+		return schema.GroupVersionResource{Group: "batch", Version: "v1beta1", Resource: "cronjobs"}, false, nil
+	case "po":
+		return schema.GroupVersionResource{Version: "v1", Resource: "pods"}, false, nil
+	case "svc":
+		return schema.GroupVersionResource{Version: "v1", Resource: "services"}, false, nil
+	case "ing":
+		return schema.GroupVersionResource{Group: "networking.k8s.io", Version: "v1beta1", Resource: "ingresses"}, false, nil
+	case "pvc":
+		return schema.GroupVersionResource{Version: "v1", Resource: "persistentvolumeclaims"}, false, nil
+	case "rollout":
+		return argorollout.RolloutsResource, true, nil
+	}
+
+	return schema.GroupVersionResource{}, false, fmt.Errorf("deletion of %s is not supported", kind)
+}
+
+func (mt *multitracker) TrackDeletion(kind string, spec MultitrackSpec, mtCtx *multitrackerContext) error {
+	gvr, trackPods, err := mt.deletionResource(kind, spec)
+	if err != nil {
+		return err
+	}
+
+	opts := newMultitrackOptions(mtCtx.Context, mt.opts.Timeout, mt.opts.StatusProgressPeriod, mt.opts.LogsFromTime)
+	id := resourceID(kind, spec)
+
+	feed := deletion.NewFeed()
+
+	feed.OnAdded(func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.DeletionsStatuses[id] = feed.GetStatus()
+
+		mt.handleResourceAdded(mt.deletionResourcesStates(kind, spec), spec)
+
+		mt.displayResourceTrackerMessageF(kind, spec, "waiting for deletion")
+		mt.emitResourceEvent(kind, spec, Event{Type: ResourceAddedEvent})
+
+		return nil
+	})
+	feed.OnDeleted(func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.DeletionsStatuses[id] = feed.GetStatus()
+
+		mt.displayResourceTrackerMessageF(kind, spec, "DELETED")
+		mt.emitResourceEvent(kind, spec, Event{Type: ResourceReadyEvent})
+
+		return mt.handleResourceReadyCondition(mt.deletionResourcesStates(kind, spec), spec)
+	})
+	feed.OnStatus(func(status deletion.DeletionStatus) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.DeletionsStatuses[id] = status
+
+		return nil
+	})
+
+	return feed.Track(kind, spec.ResourceName, spec.Namespace, gvr, trackPods, mt.kube, mt.dynamicClient, opts.Options)
+}
+
+// deletionResourcesStates returns tracking states of the resources of the kind
+func (mt *multitracker) deletionResourcesStates(kind string, spec MultitrackSpec) map[string]*multitrackerResourceState {
+	for _, k := range mt.trackedKinds() {
+		if k.ResourceKind(spec) != kind {
+			continue
+		}
+		if _, hasKey := k.States[resourceKey(spec)]; hasKey {
+			return k.States
+		}
+	}
+	return nil
+}
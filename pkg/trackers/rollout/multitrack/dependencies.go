@@ -0,0 +1,196 @@
+package multitrack
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// resolveSpecsDependencies converts DependsOn references of all specs into resources ids
+// and checks that all references are known and there are no dependency cycles.
+//
+// Reference format is kind/name for the resource in the same namespace
+// or namespace/kind/name, where kind is one of: deploy, sts, ds, job, cronjob, po, svc, ing, pvc, rollout
+// or the lowercased kind of the generic resource.
+func resolveSpecsDependencies(specs MultitrackSpecs) (map[string][]string, error) {
+	knownIDs := make(map[string]bool)
+	for _, s := range allSpecs(specs) {
+		knownIDs[resourceID(s.Kind, s.Spec)] = true
+	}
+
+	dependencies := make(map[string][]string)
+
+	for _, s := range allSpecs(specs) {
+		id := resourceID(s.Kind, s.Spec)
+
+		for _, ref := range s.Spec.DependsOn {
+			depID, err := dependencyRefToID(ref, s.Spec.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", id, err)
+			}
+
+			if !knownIDs[depID] {
+				return nil, fmt.Errorf("%s depends on unknown resource %q", id, ref)
+			}
+
+			if depID == id {
+				return nil, fmt.Errorf("%s depends on itself", id)
+			}
+
+			dependencies[id] = appendElemIfNotExist(dependencies[id], depID)
+		}
+	}
+
+	// Resources matched by selectors are not known before tracking is started,
+	// so these resources could depend on other resources, but not vice versa
+	for _, spec := range specs.Selectors {
+		for _, ref := range spec.DependsOn {
+			depID, err := dependencyRefToID(ref, spec.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("selector %q: %s", spec.LabelSelector, err)
+			}
+
+			if !knownIDs[depID] {
+				return nil, fmt.Errorf("selector %q depends on unknown resource %q", spec.LabelSelector, ref)
+			}
+		}
+	}
+
+	if err := checkDependencyCycles(dependencies); err != nil {
+		return nil, err
+	}
+
+	return dependencies, nil
+}
+
+type kindSpec struct {
+	Kind string
+	Spec MultitrackSpec
+}
+
+// allSpecs returns specs of all kinds along with the short kind of each spec, in the same order as trackedKinds
+func allSpecs(specs MultitrackSpecs) []kindSpec {
+	var res []kindSpec
+
+	for _, kindSpecs := range []struct {
+		Kind  string
+		Specs []MultitrackSpec
+	}{
+		{"deploy", specs.Deployments},
+		{"sts", specs.StatefulSets},
+		{"ds", specs.DaemonSets},
+		{"job", specs.Jobs},
+		{"cronjob", specs.CronJobs},
+		{"po", specs.Pods},
+		{"svc", specs.Services},
+		{"ing", specs.Ingresses},
+		{"pvc", specs.PersistentVolumeClaims},
+		{"rollout", specs.Rollouts},
+	} {
+		for _, spec := range kindSpecs.Specs {
+			res = append(res, kindSpec{Kind: kindSpecs.Kind, Spec: spec})
+		}
+	}
+
+	for _, spec := range specs.Generic {
+		res = append(res, kindSpec{Kind: genericResourceKind(spec), Spec: spec})
+	}
+
+	return res
+}
+
+func dependencyRefToID(ref, defaultNamespace string) (string, error) {
+	parts := strings.Split(ref, "/")
+
+	var namespace, kind, name string
+	switch len(parts) {
+	case 2:
+		namespace, kind, name = defaultNamespace, parts[0], parts[1]
+	case 3:
+		namespace, kind, name = parts[0], parts[1], parts[2]
+	default:
+		return "", fmt.Errorf("bad dependency reference %q: expected kind/name or namespace/kind/name", ref)
+	}
+
+	if kind == "" || name == "" {
+		return "", fmt.Errorf("bad dependency reference %q: empty kind or name", ref)
+	}
+
+	return fmt.Sprintf("%s/%s/%s", namespace, kind, name), nil
+}
+
+func checkDependencyCycles(dependencies map[string][]string) error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	marks := make(map[string]int)
+
+	var visit func(id string, path []string) error
+	visit = func(id string, path []string) error {
+		path = append(path, id)
+
+		switch marks[id] {
+		case visiting:
+			for i := range path {
+				if path[i] == id {
+					path = path[i:]
+					break
+				}
+			}
+			return fmt.Errorf("dependency cycle detected: %s", strings.Join(path, " -> "))
+		case visited:
+			return nil
+		}
+
+		marks[id] = visiting
+		for _, depID := range dependencies[id] {
+			if err := visit(depID, path); err != nil {
+				return err
+			}
+		}
+		marks[id] = visited
+
+		return nil
+	}
+
+	// Visit resources in the sorted order, so the same cycle is reported on each run
+	var ids []string
+	for id := range dependencies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		if err := visit(id, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getPendingDependencies returns names of the resource dependencies, which are not ready yet.
+// Failed and timed out dependencies are not pending: there is nothing to wait for.
+func (mt *multitracker) getPendingDependencies(kind string, spec MultitrackSpec) []string {
+	var res []string
+
+	for _, depID := range mt.dependencies[resourceID(kind, spec)] {
+		for _, k := range mt.trackedKinds() {
+			for name, state := range k.States {
+				depSpec := k.Specs[name]
+				if resourceID(k.ResourceKind(depSpec), depSpec) != depID {
+					continue
+				}
+
+				if state.Status != resourceSucceeded && state.Status != resourceFailed && state.Status != resourceTimedOut {
+					res = append(res, mt.fullResourceName(k.ResourceKind(depSpec), depSpec))
+				}
+			}
+		}
+	}
+
+	return res
+}
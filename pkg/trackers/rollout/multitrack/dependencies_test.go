@@ -0,0 +1,208 @@
+package multitrack
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDependencyRefToID(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{ref: "job/migrate", want: "myns/job/migrate"},
+		{ref: "otherns/deploy/app", want: "otherns/deploy/app"},
+		{ref: "certificate/mycert", want: "myns/certificate/mycert"},
+		{ref: "migrate", wantErr: "expected kind/name or namespace/kind/name"},
+		{ref: "a/b/c/d", wantErr: "expected kind/name or namespace/kind/name"},
+		{ref: "job/", wantErr: "empty kind or name"},
+		{ref: "/migrate", wantErr: "empty kind or name"},
+		{ref: "ns//migrate", wantErr: "empty kind or name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := dependencyRefToID(tt.ref, "myns")
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCheckDependencyCycles(t *testing.T) {
+	tests := []struct {
+		name         string
+		dependencies map[string][]string
+		wantErr      bool
+	}{
+		{name: "no dependencies", dependencies: map[string][]string{}},
+		{name: "chain", dependencies: map[string][]string{"a": {"b"}, "b": {"c"}}},
+		{name: "diamond", dependencies: map[string][]string{"a": {"b", "c"}, "b": {"d"}, "c": {"d"}}},
+		{name: "two nodes cycle", dependencies: map[string][]string{"a": {"b"}, "b": {"a"}}, wantErr: true},
+		{name: "long cycle", dependencies: map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"d"}, "d": {"b"}}, wantErr: true},
+		{name: "self cycle", dependencies: map[string][]string{"a": {"a"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDependencyCycles(tt.dependencies)
+
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "dependency cycle detected") {
+					t.Fatalf("expected dependency cycle error, got %v", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestCheckDependencyCyclesPath(t *testing.T) {
+	err := checkDependencyCycles(map[string][]string{"b": {"c"}, "c": {"b"}})
+	if err == nil {
+		t.Fatal("expected dependency cycle error, got nil")
+	}
+
+	if msg := err.Error(); msg != "dependency cycle detected: b -> c -> b" {
+		t.Fatalf("unexpected error %q", msg)
+	}
+}
+
+func TestResolveSpecsDependencies(t *testing.T) {
+	tests := []struct {
+		name    string
+		specs   MultitrackSpecs
+		want    map[string][]string
+		wantErr string
+	}{
+		{
+			name: "same namespace and explicit namespace references",
+			specs: MultitrackSpecs{
+				Deployments: []MultitrackSpec{{ResourceName: "app", Namespace: "myns", DependsOn: []string{"job/migrate", "otherns/svc/db", "job/migrate"}}},
+				Jobs:        []MultitrackSpec{{ResourceName: "migrate", Namespace: "myns"}},
+				Services:    []MultitrackSpec{{ResourceName: "db", Namespace: "otherns"}},
+			},
+			want: map[string][]string{"myns/deploy/app": {"myns/job/migrate", "otherns/svc/db"}},
+		},
+		{
+			name: "generic resource reference",
+			specs: MultitrackSpecs{
+				Deployments: []MultitrackSpec{{ResourceName: "app", Namespace: "myns", DependsOn: []string{"certificate/mycert"}}},
+				Generic:     []MultitrackSpec{{ResourceName: "mycert", Namespace: "myns", Kind: "Certificate"}},
+			},
+			want: map[string][]string{"myns/deploy/app": {"myns/certificate/mycert"}},
+		},
+		{
+			name: "unknown resource",
+			specs: MultitrackSpecs{
+				Deployments: []MultitrackSpec{{ResourceName: "app", Namespace: "myns", DependsOn: []string{"job/migrate"}}},
+			},
+			wantErr: `myns/deploy/app depends on unknown resource "job/migrate"`,
+		},
+		{
+			name: "resource in other namespace is unknown",
+			specs: MultitrackSpecs{
+				Deployments: []MultitrackSpec{{ResourceName: "app", Namespace: "myns", DependsOn: []string{"job/migrate"}}},
+				Jobs:        []MultitrackSpec{{ResourceName: "migrate", Namespace: "otherns"}},
+			},
+			wantErr: "depends on unknown resource",
+		},
+		{
+			name: "depends on itself",
+			specs: MultitrackSpecs{
+				Deployments: []MultitrackSpec{{ResourceName: "app", Namespace: "myns", DependsOn: []string{"deploy/app"}}},
+			},
+			wantErr: "myns/deploy/app depends on itself",
+		},
+		{
+			name: "bad reference",
+			specs: MultitrackSpecs{
+				Deployments: []MultitrackSpec{{ResourceName: "app", Namespace: "myns", DependsOn: []string{"migrate"}}},
+			},
+			wantErr: "bad dependency reference",
+		},
+		{
+			name: "cycle",
+			specs: MultitrackSpecs{
+				Deployments: []MultitrackSpec{{ResourceName: "app", Namespace: "myns", DependsOn: []string{"job/migrate"}}},
+				Jobs:        []MultitrackSpec{{ResourceName: "migrate", Namespace: "myns", DependsOn: []string{"deploy/app"}}},
+			},
+			wantErr: "dependency cycle detected",
+		},
+		{
+			name: "selector depends on known resource",
+			specs: MultitrackSpecs{
+				Jobs:      []MultitrackSpec{{ResourceName: "migrate", Namespace: "myns"}},
+				Selectors: []MultitrackSpec{{LabelSelector: "app=web", Namespace: "myns", DependsOn: []string{"job/migrate"}}},
+			},
+			want: map[string][]string{},
+		},
+		{
+			name: "selector depends on unknown resource",
+			specs: MultitrackSpecs{
+				Selectors: []MultitrackSpec{{LabelSelector: "app=web", Namespace: "myns", DependsOn: []string{"job/migrate"}}},
+			},
+			wantErr: `selector "app=web" depends on unknown resource "job/migrate"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveSpecsDependencies(tt.specs)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAllSpecsOrder(t *testing.T) {
+	specs := MultitrackSpecs{
+		Generic:     []MultitrackSpec{{ResourceName: "mycert", Namespace: "myns", Kind: "Certificate"}},
+		Rollouts:    []MultitrackSpec{{ResourceName: "canary", Namespace: "myns"}},
+		Jobs:        []MultitrackSpec{{ResourceName: "migrate", Namespace: "myns"}, {ResourceName: "seed", Namespace: "myns"}},
+		Deployments: []MultitrackSpec{{ResourceName: "app", Namespace: "myns"}},
+		Pods:        []MultitrackSpec{{ResourceName: "debug", Namespace: "myns"}},
+	}
+
+	want := []string{"myns/deploy/app", "myns/job/migrate", "myns/job/seed", "myns/po/debug", "myns/rollout/canary", "myns/certificate/mycert"}
+
+	for i := 0; i < 10; i++ {
+		var got []string
+		for _, s := range allSpecs(specs) {
+			got = append(got, resourceID(s.Kind, s.Spec))
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
@@ -15,7 +15,7 @@ func (mt *multitracker) TrackDeployment(kube kubernetes.Interface, spec Multitra
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DeploymentsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DeploymentsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.deploymentAdded(spec, feed, isReady)
 	})
@@ -23,7 +23,7 @@ func (mt *multitracker) TrackDeployment(kube kubernetes.Interface, spec Multitra
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DeploymentsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DeploymentsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.deploymentReady(spec, feed)
 	})
@@ -31,7 +31,7 @@ func (mt *multitracker) TrackDeployment(kube kubernetes.Interface, spec Multitra
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DeploymentsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DeploymentsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.deploymentFailed(spec, feed, reason)
 	})
@@ -39,7 +39,7 @@ func (mt *multitracker) TrackDeployment(kube kubernetes.Interface, spec Multitra
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DeploymentsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DeploymentsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.deploymentEventMsg(spec, feed, msg)
 	})
@@ -47,7 +47,7 @@ func (mt *multitracker) TrackDeployment(kube kubernetes.Interface, spec Multitra
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DeploymentsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DeploymentsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.deploymentAddedReplicaSet(spec, feed, rs)
 	})
@@ -55,7 +55,7 @@ func (mt *multitracker) TrackDeployment(kube kubernetes.Interface, spec Multitra
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DeploymentsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DeploymentsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.deploymentAddedPod(spec, feed, pod)
 	})
@@ -63,7 +63,7 @@ func (mt *multitracker) TrackDeployment(kube kubernetes.Interface, spec Multitra
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DeploymentsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DeploymentsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.deploymentPodError(spec, feed, podError)
 	})
@@ -71,7 +71,7 @@ func (mt *multitracker) TrackDeployment(kube kubernetes.Interface, spec Multitra
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DeploymentsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.DeploymentsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.deploymentPodLogChunk(spec, feed, chunk)
 	})
@@ -79,34 +79,44 @@ func (mt *multitracker) TrackDeployment(kube kubernetes.Interface, spec Multitra
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.DeploymentsStatuses[spec.ResourceName] = status
+		mt.DeploymentsStatuses[resourceKey(spec)] = status
 
-		return nil
+		if !status.IsFailed && !hasPodsErrors(status.Pods, status.NewPodsNames) {
+			mt.resetResourceFailure(mt.TrackingDeployments, spec)
+		}
+
+		return mt.handleResourceReadyStability(mt.TrackingDeployments, "deploy", spec, status.IsReady, podsRestartsCount(status.Pods, status.NewPodsNames))
 	})
 
 	return feed.Track(spec.ResourceName, spec.Namespace, kube, opts.Options)
 }
 
 func (mt *multitracker) deploymentAdded(spec MultitrackSpec, feed deployment.Feed, isReady bool) error {
+	mt.handleResourceAdded(mt.TrackingDeployments, spec)
+
 	if isReady {
 		mt.displayResourceTrackerMessageF("deploy", spec, "appears to be READY")
+		mt.emitResourceEvent("deploy", spec, Event{Type: ResourceReadyEvent})
 
 		return mt.handleResourceReadyCondition(mt.TrackingDeployments, spec)
 	}
 
 	mt.displayResourceTrackerMessageF("deploy", spec, "added")
+	mt.emitResourceEvent("deploy", spec, Event{Type: ResourceAddedEvent})
 
 	return nil
 }
 
 func (mt *multitracker) deploymentReady(spec MultitrackSpec, feed deployment.Feed) error {
 	mt.displayResourceTrackerMessageF("deploy", spec, "become READY")
+	mt.emitResourceEvent("deploy", spec, Event{Type: ResourceReadyEvent})
 
 	return mt.handleResourceReadyCondition(mt.TrackingDeployments, spec)
 }
 
 func (mt *multitracker) deploymentFailed(spec MultitrackSpec, feed deployment.Feed, reason string) error {
 	mt.displayResourceErrorF("deploy", spec, "%s", reason)
+	mt.emitResourceEvent("deploy", spec, Event{Type: ResourceFailedEvent, Message: reason})
 
 	return mt.handleResourceFailure(mt.TrackingDeployments, "deploy", spec, reason)
 }
@@ -132,6 +142,7 @@ func (mt *multitracker) deploymentAddedPod(spec MultitrackSpec, feed deployment.
 	}
 
 	mt.displayResourceTrackerMessageF("deploy", spec, "po/%s added", pod.Name)
+	mt.emitResourceEvent("deploy", spec, Event{Type: PodAddedEvent, Pod: pod.Name})
 
 	return nil
 }
@@ -142,8 +153,10 @@ func (mt *multitracker) deploymentPodError(spec MultitrackSpec, feed deployment.
 	}
 
 	reason := fmt.Sprintf("po/%s container/%s: %s", podError.PodName, podError.ContainerName, podError.Message)
+	reason = withContainerFailureHint(reason, mt.DeploymentsStatuses[resourceKey(spec)].Pods, podError.PodName, podError.ContainerName)
 
 	mt.displayResourceErrorF("deploy", spec, "%s", reason)
+	mt.emitResourceEvent("deploy", spec, Event{Type: ContainerErrorEvent, Pod: podError.PodName, Container: podError.ContainerName, Message: podError.Message})
 
 	return mt.handleResourceFailure(mt.TrackingDeployments, "deploy", spec, reason)
 }
@@ -153,14 +166,12 @@ func (mt *multitracker) deploymentPodLogChunk(spec MultitrackSpec, feed deployme
 		return nil
 	}
 
-	status := mt.DeploymentsStatuses[spec.ResourceName]
-	if podStatus, hasKey := status.Pods[chunk.PodName]; hasKey {
-		if podStatus.IsReady {
-			return nil
-		}
+	status := mt.DeploymentsStatuses[resourceKey(spec)]
+	if !isPodLogsVisible(spec, status.Pods, chunk.PodName) {
+		return nil
 	}
 
-	mt.displayResourceLogChunk("deploy", spec, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk), chunk.ContainerLogChunk)
+	mt.displayResourceLogChunk("deploy", spec, chunk.PodName, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk), chunk.ContainerLogChunk)
 
 	return nil
 }
@@ -0,0 +1,320 @@
+package multitrack
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/flant/kubedog/pkg/utils"
+)
+
+const defaultDiagnosticsLogLines = 100
+
+// diagnosticsResource is the failed resource to gather diagnostics for
+type diagnosticsResource struct {
+	Kind      string
+	Spec      MultitrackSpec
+	GVR       schema.GroupVersionResource
+	PodsNames []string
+	Reason    string
+}
+
+// diagnosticsWriter writes files of the diagnostics bundle into the directory or into the tar.gz archive
+type diagnosticsWriter interface {
+	WriteFile(name string, data []byte) error
+	Close() error
+}
+
+func newDiagnosticsWriter(bundlePath string) (diagnosticsWriter, error) {
+	if strings.HasSuffix(bundlePath, ".tar.gz") || strings.HasSuffix(bundlePath, ".tgz") {
+		file, err := os.Create(bundlePath)
+		if err != nil {
+			return nil, err
+		}
+		gzipWriter := gzip.NewWriter(file)
+		return &tarDiagnosticsWriter{file: file, gzipWriter: gzipWriter, tarWriter: tar.NewWriter(gzipWriter)}, nil
+	}
+
+	if err := os.MkdirAll(bundlePath, 0755); err != nil {
+		return nil, err
+	}
+	return &dirDiagnosticsWriter{dir: bundlePath}, nil
+}
+
+type dirDiagnosticsWriter struct {
+	dir string
+}
+
+func (w *dirDiagnosticsWriter) WriteFile(name string, data []byte) error {
+	filePath := filepath.Join(w.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, data, 0644)
+}
+
+func (w *dirDiagnosticsWriter) Close() error {
+	return nil
+}
+
+type tarDiagnosticsWriter struct {
+	file       *os.File
+	gzipWriter *gzip.Writer
+	tarWriter  *tar.Writer
+}
+
+func (w *tarDiagnosticsWriter) WriteFile(name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := w.tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := w.tarWriter.Write(data)
+	return err
+}
+
+func (w *tarDiagnosticsWriter) Close() error {
+	if err := w.tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := w.gzipWriter.Close(); err != nil {
+		return err
+	}
+	return w.file.Close()
+}
+
+// failedDiagnosticsResources returns failed and timed out resources along with the resources,
+// which are not done when tracking is stopped by the trackingErr. Should be called under the multitracker lock.
+func (mt *multitracker) failedDiagnosticsResources(trackingErr error) []diagnosticsResource {
+	var res []diagnosticsResource
+
+	for _, k := range mt.trackedKinds() {
+		for _, name := range sortedResourcesNames(k.States) {
+			state := k.States[name]
+			spec := k.Specs[name]
+
+			var reason string
+			switch {
+			case state.Status == resourceFailed || state.Status == resourceTimedOut:
+				reason = state.FailedReason
+			case isResourceNotDoneOnError(spec, state, trackingErr):
+				reason = mt.resourceNotDoneMessage(k, spec, trackingErr)
+			default:
+				continue
+			}
+
+			resource := diagnosticsResource{
+				Kind:      k.ResourceKind(spec),
+				Spec:      spec,
+				PodsNames: mt.resourcePodsNames(k, spec),
+				Reason:    reason,
+			}
+
+			gvr, _, err := mt.deletionResource(k.Kind, spec)
+			if err == nil {
+				resource.GVR = gvr
+			}
+
+			res = append(res, resource)
+		}
+	}
+
+	return res
+}
+
+// resourcePodsNames returns names of all known pods of the resource accordingly to its last status
+func (mt *multitracker) resourcePodsNames(k multitrackerKind, spec MultitrackSpec) []string {
+	if spec.TrackTerminationMode == WaitUntilResourceDeleted {
+		var names []string
+		for name := range mt.DeletionsStatuses[resourceID(k.ResourceKind(spec), spec)].Pods {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	key := resourceKey(spec)
+
+	switch k.Kind {
+	case "deploy":
+		return podsNames(mt.DeploymentsStatuses[key].Pods)
+	case "sts":
+		return podsNames(mt.StatefulSetsStatuses[key].Pods)
+	case "ds":
+		return podsNames(mt.DaemonSetsStatuses[key].Pods)
+	case "job":
+		return podsNames(mt.JobsStatuses[key].Pods)
+	case "cronjob":
+		var names []string
+		for _, jobStatus := range mt.CronJobsStatuses[key].Jobs {
+			names = append(names, podsNames(jobStatus.Pods)...)
+		}
+		sort.Strings(names)
+		return names
+	case "po":
+		return []string{spec.ResourceName}
+	case "rollout":
+		return podsNames(mt.RolloutsStatuses[key].Pods)
+	}
+
+	return nil
+}
+
+// writeDiagnostics gathers diagnostics bundle of the failed resources: object, events, pods and last logs of the containers.
+// Errors of gathering of the separate items are saved into the errors.txt of the resource.
+// Returns the number of the resources in the bundle, the bundle is not written when there are no such resources.
+func (mt *multitracker) writeDiagnostics(bundlePath string, logLines int, trackingErr error) (int, error) {
+	resources := func() []diagnosticsResource {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+		return mt.failedDiagnosticsResources(trackingErr)
+	}()
+
+	if len(resources) == 0 {
+		return 0, nil
+	}
+
+	if logLines <= 0 {
+		logLines = defaultDiagnosticsLogLines
+	}
+
+	w, err := newDiagnosticsWriter(bundlePath)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, resource := range resources {
+		if err := mt.writeResourceDiagnostics(w, resource, int64(logLines)); err != nil {
+			_ = w.Close()
+			return 0, err
+		}
+	}
+
+	return len(resources), w.Close()
+}
+
+func (mt *multitracker) writeResourceDiagnostics(w diagnosticsWriter, resource diagnosticsResource, logLines int64) error {
+	spec := resource.Spec
+	dir := fmt.Sprintf("%s_%s_%s", spec.Namespace, resource.Kind, spec.ResourceName)
+
+	var gatherErrors []string
+	addError := func(format string, a ...interface{}) {
+		gatherErrors = append(gatherErrors, fmt.Sprintf(format, a...))
+	}
+
+	if err := w.WriteFile(path.Join(dir, "reason.txt"), []byte(resource.Reason+"\n")); err != nil {
+		return err
+	}
+
+	if resource.GVR.Resource == "" {
+		addError("unable to get %s/%s: unknown resource", resource.Kind, spec.ResourceName)
+	} else if obj, err := mt.dynamicClient.Resource(resource.GVR).Namespace(spec.Namespace).Get(spec.ResourceName, metav1.GetOptions{}); err != nil {
+		addError("unable to get %s/%s: %s", resource.Kind, spec.ResourceName, err)
+	} else {
+		if err := writeDiagnosticsYaml(w, path.Join(dir, "object.yaml"), obj.Object); err != nil {
+			return err
+		}
+
+		if err := mt.writeDiagnosticsEvents(w, path.Join(dir, "events.yaml"), obj); err != nil {
+			addError("unable to list events of %s/%s: %s", resource.Kind, spec.ResourceName, err)
+		}
+	}
+
+	for _, podName := range resource.PodsNames {
+		pod, err := mt.kube.CoreV1().Pods(spec.Namespace).Get(podName, metav1.GetOptions{})
+		if err != nil {
+			addError("unable to get po/%s: %s", podName, err)
+			continue
+		}
+
+		podDir := path.Join(dir, "pods", podName)
+
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+		if err != nil {
+			return err
+		}
+		obj["apiVersion"], obj["kind"] = "v1", "Pod"
+		if err := writeDiagnosticsYaml(w, path.Join(podDir, "pod.yaml"), obj); err != nil {
+			return err
+		}
+
+		if err := mt.writeDiagnosticsEvents(w, path.Join(podDir, "events.yaml"), pod); err != nil {
+			addError("unable to list events of po/%s: %s", podName, err)
+		}
+
+		var containersStatuses []corev1.ContainerStatus
+		containersStatuses = append(containersStatuses, pod.Status.InitContainerStatuses...)
+		containersStatuses = append(containersStatuses, pod.Status.ContainerStatuses...)
+
+		for _, containerStatus := range containersStatuses {
+			logs, err := mt.kube.CoreV1().Pods(spec.Namespace).GetLogs(podName, &corev1.PodLogOptions{Container: containerStatus.Name, TailLines: &logLines}).DoRaw()
+			if err != nil {
+				addError("unable to get po/%s container/%s logs: %s", podName, containerStatus.Name, err)
+			} else if err := w.WriteFile(path.Join(podDir, fmt.Sprintf("%s.log", containerStatus.Name)), logs); err != nil {
+				return err
+			}
+
+			if containerStatus.RestartCount == 0 {
+				continue
+			}
+
+			logs, err = mt.kube.CoreV1().Pods(spec.Namespace).GetLogs(podName, &corev1.PodLogOptions{Container: containerStatus.Name, TailLines: &logLines, Previous: true}).DoRaw()
+			if err != nil {
+				addError("unable to get po/%s container/%s previous logs: %s", podName, containerStatus.Name, err)
+			} else if err := w.WriteFile(path.Join(podDir, fmt.Sprintf("%s.previous.log", containerStatus.Name)), logs); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(gatherErrors) > 0 {
+		return w.WriteFile(path.Join(dir, "errors.txt"), []byte(strings.Join(gatherErrors, "\n")+"\n"))
+	}
+
+	return nil
+}
+
+func (mt *multitracker) writeDiagnosticsEvents(w diagnosticsWriter, name string, obj interface{}) error {
+	eventList, err := utils.ListEventsForObject(mt.kube, obj)
+	if err != nil {
+		return err
+	}
+
+	sort.Sort(utils.SortableEvents(eventList.Items))
+
+	var events []interface{}
+	for i := range eventList.Items {
+		event, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&eventList.Items[i])
+		if err != nil {
+			return err
+		}
+		events = append(events, event)
+	}
+
+	return writeDiagnosticsYaml(w, name, events)
+}
+
+func writeDiagnosticsYaml(w diagnosticsWriter, name string, value interface{}) error {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return w.WriteFile(name, data)
+}
@@ -0,0 +1,349 @@
+package multitrack
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/flant/kubedog/pkg/tracker/indicators"
+	"github.com/flant/kubedog/pkg/tracker/pod"
+)
+
+type EventType string
+
+const (
+	ResourceAddedEvent  EventType = "ResourceAdded"
+	ResourceReadyEvent  EventType = "ResourceReady"
+	ResourceFailedEvent EventType = "ResourceFailed"
+	// EventMessageEvent is the message of the kubernetes Event related to the resource
+	EventMessageEvent   EventType = "EventMessage"
+	PodAddedEvent       EventType = "PodAdded"
+	ContainerErrorEvent EventType = "ContainerError"
+	LogLineEvent        EventType = "LogLine"
+	// ContainerTerminatedEvent is emitted with the logs of the terminated instance of the restarted container
+	ContainerTerminatedEvent EventType = "ContainerTerminated"
+	// StatusEvent is the snapshot of the resource status, which is emitted with each status progress
+	StatusEvent EventType = "Status"
+)
+
+// Event is the machine-readable counterpart of the messages multitracker displays
+type Event struct {
+	Type      EventType `json:"type"`
+	Time      time.Time `json:"time"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+
+	Message   string `json:"message,omitempty"`
+	Pod       string `json:"pod,omitempty"`
+	Container string `json:"container,omitempty"`
+	// LogTimestamp is the timestamp of the log line reported by kubernetes
+	LogTimestamp string `json:"logTimestamp,omitempty"`
+	// PreviousInstance is set for the log lines of the terminated instance of the restarted container
+	PreviousInstance *EventContainerTermination `json:"previousInstance,omitempty"`
+
+	Status *EventStatus `json:"status,omitempty"`
+}
+
+type EventStatus struct {
+	// State is the tracking state of the resource: Active, Succeeded, Failed, Hoping, ActiveAfterHoping or TimedOut
+	State         string   `json:"state"`
+	IsReady       bool     `json:"isReady"`
+	IsFailed      bool     `json:"isFailed"`
+	FailedReason  string   `json:"failedReason,omitempty"`
+	FailuresCount int      `json:"failuresCount"`
+	WaitingFor    []string `json:"waitingFor,omitempty"`
+
+	// Indicators are the values shown in the status progress tables, map by the indicator name
+	Indicators map[string]EventIndicator `json:"indicators,omitempty"`
+	// Pods of the resource (new Pods for the controllers), map by Pod name
+	Pods map[string]EventPodStatus `json:"pods,omitempty"`
+}
+
+type EventContainerTermination struct {
+	RestartCount int32  `json:"restartCount"`
+	ExitCode     int32  `json:"exitCode"`
+	Signal       int32  `json:"signal,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+	Message      string `json:"message,omitempty"`
+}
+
+type EventIndicator struct {
+	Value  interface{} `json:"value"`
+	Target interface{} `json:"target,omitempty"`
+}
+
+type EventPodStatus struct {
+	Status          string `json:"status"`
+	ReadyContainers int32  `json:"readyContainers"`
+	TotalContainers int32  `json:"totalContainers"`
+	Restarts        int32  `json:"restarts"`
+	IsReady         bool   `json:"isReady"`
+	IsFailed        bool   `json:"isFailed"`
+	FailedReason    string `json:"failedReason,omitempty"`
+	// Hints describe the classified causes of the containers problems, such as OOMKilled container
+	Hints []string `json:"hints,omitempty"`
+}
+
+// EventSink receives all multitracker events. HandleEvent is called under the multitracker lock, so it should not block.
+type EventSink interface {
+	HandleEvent(event Event)
+}
+
+type jsonEventSink struct {
+	mux     sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONEventSink returns EventSink, which writes each event as a JSON object on a separate line.
+// Write errors are ignored, the same as for the human-readable output.
+func NewJSONEventSink(w io.Writer) EventSink {
+	return &jsonEventSink{encoder: json.NewEncoder(w)}
+}
+
+func (s *jsonEventSink) HandleEvent(event Event) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	_ = s.encoder.Encode(event)
+}
+
+func (mt *multitracker) emitResourceEvent(kind string, spec MultitrackSpec, event Event) {
+	if mt.opts.EventSink == nil {
+		return
+	}
+
+	event.Time = time.Now()
+	event.Kind = kind
+	event.Namespace = spec.Namespace
+	event.Name = spec.ResourceName
+
+	mt.opts.EventSink.HandleEvent(event)
+}
+
+func (mt *multitracker) emitStatusEvents() {
+	if mt.opts.EventSink == nil {
+		return
+	}
+
+	for _, k := range mt.trackedKinds() {
+		for _, name := range sortedResourcesNames(k.States) {
+			spec := k.Specs[name]
+			mt.emitResourceEvent(k.ResourceKind(spec), spec, Event{Type: StatusEvent, Status: mt.resourceEventStatus(k, spec)})
+		}
+	}
+}
+
+func (mt *multitracker) resourceEventStatus(k multitrackerKind, spec MultitrackSpec) *EventStatus {
+	state := k.States[resourceKey(spec)]
+
+	res := &EventStatus{
+		State:         strings.TrimPrefix(string(state.Status), "resource"),
+		FailedReason:  state.FailedReason,
+		FailuresCount: state.FailuresCount,
+		IsFailed:      state.Status == resourceFailed,
+		WaitingFor:    mt.resourceWaitingForMessages(k, spec),
+		Indicators:    make(map[string]EventIndicator),
+	}
+
+	if spec.TrackTerminationMode == WaitUntilResourceDeleted {
+		status := mt.DeletionsStatuses[resourceID(k.ResourceKind(spec), spec)]
+		res.IsReady = status.IsDeleted
+		res.Indicators["exists"] = EventIndicator{Value: status.IsExists, Target: false}
+		res.Indicators["pods"] = EventIndicator{Value: len(status.Pods), Target: 0}
+		return res
+	}
+
+	key := resourceKey(spec)
+
+	switch k.Kind {
+	case "deploy":
+		status := mt.DeploymentsStatuses[key]
+		res.IsReady, res.IsFailed = status.IsReady, res.IsFailed || status.IsFailed
+		setInt32EventIndicator(res.Indicators, "replicas", status.ReplicasIndicator)
+		setInt32EventIndicator(res.Indicators, "upToDate", status.UpToDateIndicator)
+		setInt32EventIndicator(res.Indicators, "available", status.AvailableIndicator)
+		res.Pods = eventPodsStatuses(status.Pods, status.NewPodsNames)
+
+	case "sts":
+		status := mt.StatefulSetsStatuses[key]
+		res.IsReady, res.IsFailed = status.IsReady, res.IsFailed || status.IsFailed
+		setInt64EventIndicator(res.Indicators, "replicas", status.ReplicasIndicator)
+		setInt64EventIndicator(res.Indicators, "ready", status.ReadyIndicator)
+		setInt64EventIndicator(res.Indicators, "upToDate", status.UpToDateIndicator)
+		res.Pods = eventPodsStatuses(status.Pods, status.NewPodsNames)
+
+	case "ds":
+		status := mt.DaemonSetsStatuses[key]
+		res.IsReady, res.IsFailed = status.IsReady, res.IsFailed || status.IsFailed
+		setInt32EventIndicator(res.Indicators, "replicas", status.ReplicasIndicator)
+		setInt32EventIndicator(res.Indicators, "upToDate", status.UpToDateIndicator)
+		setInt32EventIndicator(res.Indicators, "available", status.AvailableIndicator)
+		res.Pods = eventPodsStatuses(status.Pods, status.NewPodsNames)
+
+	case "job":
+		status := mt.JobsStatuses[key]
+		res.IsReady, res.IsFailed = status.IsSucceeded, res.IsFailed || status.IsFailed
+		setInt32EventIndicator(res.Indicators, "succeeded", status.SucceededIndicator)
+		res.Indicators["active"] = EventIndicator{Value: status.Active}
+		res.Indicators["failed"] = EventIndicator{Value: status.Failed}
+		res.Pods = eventPodsStatuses(status.Pods, podsNames(status.Pods))
+
+	case "cronjob":
+		status := mt.CronJobsStatuses[key]
+		res.IsReady, res.IsFailed = status.IsReady, res.IsFailed || status.IsFailed
+		res.Indicators["activeJobs"] = EventIndicator{Value: len(status.ActiveJobsNames)}
+		res.Indicators["suspended"] = EventIndicator{Value: status.IsSuspended, Target: false}
+
+	case "po":
+		status := mt.PodsStatuses[key]
+		res.IsReady, res.IsFailed = status.IsReady || status.IsSucceeded, res.IsFailed || status.IsFailed
+		setStringEventIndicator(res.Indicators, "status", status.StatusIndicator)
+		res.Indicators["readyContainers"] = EventIndicator{Value: status.ReadyContainers, Target: status.TotalContainers}
+		res.Indicators["restarts"] = EventIndicator{Value: status.Restarts}
+
+	case "svc":
+		status := mt.ServicesStatuses[key]
+		res.IsReady, res.IsFailed = status.IsReady, res.IsFailed || status.IsFailed
+		res.Indicators["readyEndpoints"] = EventIndicator{Value: status.ReadyEndpoints, Target: status.MinReadyEndpoints}
+		res.Indicators["notReadyEndpoints"] = EventIndicator{Value: status.NotReadyEndpoints}
+
+	case "ing":
+		status := mt.IngressesStatuses[key]
+		res.IsReady, res.IsFailed = status.IsReady, res.IsFailed || status.IsFailed
+		readyBackends := 0
+		for _, backend := range status.Backends {
+			if backend.IsReady {
+				readyBackends++
+			}
+		}
+		res.Indicators["readyBackends"] = EventIndicator{Value: readyBackends, Target: len(status.Backends)}
+		res.Indicators["addresses"] = EventIndicator{Value: len(status.Addresses)}
+
+	case "pvc":
+		status := mt.PVCsStatuses[key]
+		res.IsReady, res.IsFailed = status.IsReady, res.IsFailed || status.IsFailed
+		setStringEventIndicator(res.Indicators, "phase", status.PhaseIndicator)
+
+	case "rollout":
+		status := mt.RolloutsStatuses[key]
+		res.IsReady, res.IsFailed = status.IsReady, res.IsFailed || status.IsFailed
+		setInt32EventIndicator(res.Indicators, "replicas", status.ReplicasIndicator)
+		setInt32EventIndicator(res.Indicators, "upToDate", status.UpToDateIndicator)
+		setInt32EventIndicator(res.Indicators, "available", status.AvailableIndicator)
+		if status.StepsCount > 0 {
+			res.Indicators["step"] = EventIndicator{Value: status.CurrentStepIndex, Target: status.StepsCount}
+		}
+		if status.CanaryWeight >= 0 {
+			res.Indicators["canaryWeight"] = EventIndicator{Value: status.CanaryWeight}
+		}
+		res.Pods = eventPodsStatuses(status.Pods, status.NewPodsNames)
+
+	case "generic":
+		status := mt.GenericStatuses[key]
+		res.IsReady, res.IsFailed = status.IsReady, res.IsFailed || status.IsFailed
+		for _, condition := range status.Conditions {
+			if condition.IsTracked {
+				setStringEventIndicator(res.Indicators, condition.Type, condition.Indicator)
+			}
+		}
+	}
+
+	if res.FailedReason == "" && res.IsFailed {
+		res.FailedReason = mt.resourceStatusFailedReason(k.Kind, key)
+	}
+
+	return res
+}
+
+// resourceStatusFailedReason returns the failed reason from the last status of the resource
+func (mt *multitracker) resourceStatusFailedReason(kind, key string) string {
+	switch kind {
+	case "deploy":
+		return mt.DeploymentsStatuses[key].FailedReason
+	case "sts":
+		return mt.StatefulSetsStatuses[key].FailedReason
+	case "ds":
+		return mt.DaemonSetsStatuses[key].FailedReason
+	case "job":
+		return mt.JobsStatuses[key].FailedReason
+	case "cronjob":
+		return mt.CronJobsStatuses[key].FailedReason
+	case "po":
+		return mt.PodsStatuses[key].FailedReason
+	case "svc":
+		return mt.ServicesStatuses[key].FailedReason
+	case "ing":
+		return mt.IngressesStatuses[key].FailedReason
+	case "pvc":
+		return mt.PVCsStatuses[key].FailedReason
+	case "rollout":
+		return mt.RolloutsStatuses[key].FailedReason
+	case "generic":
+		return mt.GenericStatuses[key].FailedReason
+	}
+	return ""
+}
+
+func eventPodsStatuses(pods map[string]pod.PodStatus, names []string) map[string]EventPodStatus {
+	if len(names) == 0 {
+		return nil
+	}
+
+	res := make(map[string]EventPodStatus)
+	for _, name := range names {
+		status, hasKey := pods[name]
+		if !hasKey {
+			continue
+		}
+
+		podStatus := EventPodStatus{
+			ReadyContainers: status.ReadyContainers,
+			TotalContainers: status.TotalContainers,
+			Restarts:        status.Restarts,
+			IsReady:         status.IsReady,
+			IsFailed:        status.IsFailed,
+			FailedReason:    status.FailedReason,
+			Hints:           podHints(status),
+		}
+		if status.StatusIndicator != nil {
+			podStatus.Status = status.StatusIndicator.Value
+		}
+		res[name] = podStatus
+	}
+
+	return res
+}
+
+func setInt32EventIndicator(res map[string]EventIndicator, name string, indicator *indicators.Int32EqualConditionIndicator) {
+	if indicator != nil {
+		res[name] = EventIndicator{Value: indicator.Value, Target: indicator.TargetValue}
+	}
+}
+
+func setInt64EventIndicator(res map[string]EventIndicator, name string, indicator *indicators.Int64GreaterOrEqualConditionIndicator) {
+	if indicator != nil {
+		res[name] = EventIndicator{Value: indicator.Value, Target: indicator.TargetValue}
+	}
+}
+
+func setStringEventIndicator(res map[string]EventIndicator, name string, indicator *indicators.StringEqualConditionIndicator) {
+	if indicator != nil {
+		res[name] = EventIndicator{Value: indicator.Value, Target: indicator.TargetValue}
+	}
+}
+
+func eventContainerTermination(termination *pod.ContainerTermination) *EventContainerTermination {
+	if termination == nil {
+		return nil
+	}
+
+	return &EventContainerTermination{
+		RestartCount: termination.RestartCount,
+		ExitCode:     termination.ExitCode,
+		Signal:       termination.Signal,
+		Reason:       termination.Reason,
+		Message:      termination.Message,
+	}
+}
@@ -0,0 +1,138 @@
+package multitrack
+
+import (
+	"strings"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/flant/kubedog/pkg/kube"
+	"github.com/flant/kubedog/pkg/tracker/generic"
+)
+
+// genericResourceKind returns kind of the generic resource for user messages: certificate, kafkatopic, etc.
+func genericResourceKind(spec MultitrackSpec) string {
+	return strings.ToLower(spec.Kind)
+}
+
+// genericRules parses readiness rules of the generic resource spec
+func genericRules(spec MultitrackSpec) (generic.Rules, error) {
+	rules := generic.Rules{
+		ReadyConditions:  spec.ReadyConditions,
+		FailedConditions: spec.FailedConditions,
+	}
+
+	for _, expression := range spec.ReadyJSONPaths {
+		rule, err := generic.ParseJSONPathRule(expression)
+		if err != nil {
+			return generic.Rules{}, err
+		}
+		rules.ReadyJSONPaths = append(rules.ReadyJSONPaths, rule)
+	}
+
+	for _, expression := range spec.FailedJSONPaths {
+		rule, err := generic.ParseJSONPathRule(expression)
+		if err != nil {
+			return generic.Rules{}, err
+		}
+		rules.FailedJSONPaths = append(rules.FailedJSONPaths, rule)
+	}
+
+	return rules, nil
+}
+
+func (mt *multitracker) TrackGeneric(kubeClient kubernetes.Interface, dynamicClient dynamic.Interface, spec MultitrackSpec, opts MultitrackOptions) error {
+	gvr, err := kube.GroupVersionResourceByAPIVersionAndKind(kubeClient, spec.APIVersion, spec.Kind)
+	if err != nil {
+		return err
+	}
+
+	rules, err := genericRules(spec)
+	if err != nil {
+		return err
+	}
+
+	feed := generic.NewFeed()
+
+	feed.OnAdded(func(isReady bool) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.GenericStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.genericAdded(spec, feed, isReady)
+	})
+	feed.OnReady(func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.GenericStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.genericReady(spec, feed)
+	})
+	feed.OnFailed(func(reason string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.GenericStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.genericFailed(spec, feed, reason)
+	})
+	feed.OnEventMsg(func(msg string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.GenericStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.genericEventMsg(spec, feed, msg)
+	})
+	feed.OnStatus(func(status generic.GenericStatus) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.GenericStatuses[resourceKey(spec)] = status
+
+		if !status.IsFailed {
+			mt.resetResourceFailure(mt.TrackingGeneric, spec)
+		}
+
+		return mt.handleResourceReadyStability(mt.TrackingGeneric, genericResourceKind(spec), spec, status.IsReady, 0)
+	})
+
+	return feed.Track(spec.Kind, spec.ResourceName, spec.Namespace, gvr, rules, kubeClient, dynamicClient, opts.Options)
+}
+
+func (mt *multitracker) genericAdded(spec MultitrackSpec, feed generic.Feed, isReady bool) error {
+	mt.handleResourceAdded(mt.TrackingGeneric, spec)
+
+	if isReady {
+		mt.displayResourceTrackerMessageF(genericResourceKind(spec), spec, "appears to be READY")
+		mt.emitResourceEvent(genericResourceKind(spec), spec, Event{Type: ResourceReadyEvent})
+
+		return mt.handleResourceReadyCondition(mt.TrackingGeneric, spec)
+	}
+
+	mt.displayResourceTrackerMessageF(genericResourceKind(spec), spec, "added")
+	mt.emitResourceEvent(genericResourceKind(spec), spec, Event{Type: ResourceAddedEvent})
+
+	return nil
+}
+
+func (mt *multitracker) genericReady(spec MultitrackSpec, feed generic.Feed) error {
+	mt.displayResourceTrackerMessageF(genericResourceKind(spec), spec, "become READY")
+	mt.emitResourceEvent(genericResourceKind(spec), spec, Event{Type: ResourceReadyEvent})
+
+	return mt.handleResourceReadyCondition(mt.TrackingGeneric, spec)
+}
+
+func (mt *multitracker) genericFailed(spec MultitrackSpec, feed generic.Feed, reason string) error {
+	mt.displayResourceErrorF(genericResourceKind(spec), spec, "%s", reason)
+	mt.emitResourceEvent(genericResourceKind(spec), spec, Event{Type: ResourceFailedEvent, Message: reason})
+
+	return mt.handleResourceFailure(mt.TrackingGeneric, genericResourceKind(spec), spec, reason)
+}
+
+func (mt *multitracker) genericEventMsg(spec MultitrackSpec, feed generic.Feed, msg string) error {
+	mt.displayResourceEventF(genericResourceKind(spec), spec, "%s", msg)
+	return nil
+}
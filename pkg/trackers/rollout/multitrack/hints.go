@@ -0,0 +1,72 @@
+package multitrack
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/flant/kubedog/pkg/tracker/pod"
+)
+
+// containerFailureHint describes the classified cause of the container problem
+func containerFailureHint(containerName string, failure pod.ContainerFailure) string {
+	var hint string
+
+	switch failure.Reason {
+	case pod.ContainerOOMKilled:
+		if failure.MemoryLimit != "" {
+			hint = fmt.Sprintf("container %s was OOMKilled at %s limit", containerName, failure.MemoryLimit)
+		} else {
+			hint = fmt.Sprintf("container %s was OOMKilled, memory limit is not set", containerName)
+		}
+	case pod.ContainerNonZeroExit:
+		if failure.Signal != 0 {
+			hint = fmt.Sprintf("container %s was killed by signal %d", containerName, failure.Signal)
+		} else {
+			hint = fmt.Sprintf("container %s exited with code %d", containerName, failure.ExitCode)
+		}
+	case pod.ContainerEvicted:
+		hint = "pod was evicted"
+	case pod.ContainerLivenessProbeFailed:
+		hint = fmt.Sprintf("container %s liveness probe failed, container is restarted", containerName)
+	case pod.ContainerReadinessProbeFailed:
+		hint = fmt.Sprintf("container %s readiness probe failed", containerName)
+	case pod.ContainerCreateContainerConfigError:
+		hint = fmt.Sprintf("container %s config is invalid, check referenced ConfigMaps and Secrets", containerName)
+	case pod.ContainerInvalidImageName:
+		hint = fmt.Sprintf("container %s image name is invalid", containerName)
+	default:
+		hint = fmt.Sprintf("container %s %s", containerName, failure.Reason)
+	}
+
+	if failure.Message != "" {
+		hint = fmt.Sprintf("%s: %s", hint, failure.Message)
+	}
+
+	return hint
+}
+
+// podHints returns hints about the problems of the pod containers, the same hint is returned once
+func podHints(status pod.PodStatus) []string {
+	var containersNames []string
+	for containerName := range status.ContainersFailures {
+		containersNames = append(containersNames, containerName)
+	}
+	sort.Strings(containersNames)
+
+	var hints []string
+	for _, containerName := range containersNames {
+		hints = appendElemIfNotExist(hints, containerFailureHint(containerName, status.ContainersFailures[containerName]))
+	}
+
+	return hints
+}
+
+// withContainerFailureHint appends the hint about the cause of the container problem to the failure reason
+func withContainerFailureHint(reason string, pods map[string]pod.PodStatus, podName, containerName string) string {
+	failure, hasKey := pods[podName].ContainersFailures[containerName]
+	if !hasKey {
+		return reason
+	}
+
+	return fmt.Sprintf("%s (%s)", reason, containerFailureHint(containerName, failure))
+}
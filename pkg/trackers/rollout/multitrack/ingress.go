@@ -0,0 +1,93 @@
+package multitrack
+
+import (
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/flant/kubedog/pkg/tracker/ingress"
+)
+
+func (mt *multitracker) TrackIngress(kube kubernetes.Interface, spec MultitrackSpec, opts MultitrackOptions) error {
+	feed := ingress.NewFeed()
+
+	feed.OnAdded(func(isReady bool) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.IngressesStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.ingressAdded(spec, feed, isReady)
+	})
+	feed.OnReady(func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.IngressesStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.ingressReady(spec, feed)
+	})
+	feed.OnFailed(func(reason string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.IngressesStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.ingressFailed(spec, feed, reason)
+	})
+	feed.OnEventMsg(func(msg string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.IngressesStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.ingressEventMsg(spec, feed, msg)
+	})
+	feed.OnStatus(func(status ingress.IngressStatus) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.IngressesStatuses[resourceKey(spec)] = status
+
+		if !status.IsFailed {
+			mt.resetResourceFailure(mt.TrackingIngresses, spec)
+		}
+
+		return mt.handleResourceReadyStability(mt.TrackingIngresses, "ing", spec, status.IsReady, 0)
+	})
+
+	return feed.Track(spec.ResourceName, spec.Namespace, kube, opts.Options)
+}
+
+func (mt *multitracker) ingressAdded(spec MultitrackSpec, feed ingress.Feed, isReady bool) error {
+	mt.handleResourceAdded(mt.TrackingIngresses, spec)
+
+	if isReady {
+		mt.displayResourceTrackerMessageF("ing", spec, "appears to be READY")
+		mt.emitResourceEvent("ing", spec, Event{Type: ResourceReadyEvent})
+
+		return mt.handleResourceReadyCondition(mt.TrackingIngresses, spec)
+	}
+
+	mt.displayResourceTrackerMessageF("ing", spec, "added")
+	mt.emitResourceEvent("ing", spec, Event{Type: ResourceAddedEvent})
+
+	return nil
+}
+
+func (mt *multitracker) ingressReady(spec MultitrackSpec, feed ingress.Feed) error {
+	mt.displayResourceTrackerMessageF("ing", spec, "become READY")
+	mt.emitResourceEvent("ing", spec, Event{Type: ResourceReadyEvent})
+
+	return mt.handleResourceReadyCondition(mt.TrackingIngresses, spec)
+}
+
+func (mt *multitracker) ingressFailed(spec MultitrackSpec, feed ingress.Feed, reason string) error {
+	mt.displayResourceErrorF("ing", spec, "%s", reason)
+	mt.emitResourceEvent("ing", spec, Event{Type: ResourceFailedEvent, Message: reason})
+
+	return mt.handleResourceFailure(mt.TrackingIngresses, "ing", spec, reason)
+}
+
+func (mt *multitracker) ingressEventMsg(spec MultitrackSpec, feed ingress.Feed, msg string) error {
+	mt.displayResourceEventF("ing", spec, "%s", msg)
+	return nil
+}
@@ -15,7 +15,7 @@ func (mt *multitracker) TrackJob(kube kubernetes.Interface, spec MultitrackSpec,
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.JobsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.JobsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.jobAdded(spec, feed)
 	})
@@ -23,7 +23,7 @@ func (mt *multitracker) TrackJob(kube kubernetes.Interface, spec MultitrackSpec,
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.JobsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.JobsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.jobSucceeded(spec, feed)
 	})
@@ -31,7 +31,7 @@ func (mt *multitracker) TrackJob(kube kubernetes.Interface, spec MultitrackSpec,
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.JobsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.JobsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.jobFailed(spec, feed, reason)
 	})
@@ -39,7 +39,7 @@ func (mt *multitracker) TrackJob(kube kubernetes.Interface, spec MultitrackSpec,
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.JobsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.JobsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.jobEventMsg(spec, feed, msg)
 	})
@@ -47,7 +47,7 @@ func (mt *multitracker) TrackJob(kube kubernetes.Interface, spec MultitrackSpec,
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.JobsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.JobsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.jobAddedPod(spec, feed, podName)
 	})
@@ -55,7 +55,7 @@ func (mt *multitracker) TrackJob(kube kubernetes.Interface, spec MultitrackSpec,
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.JobsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.JobsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.jobPodLogChunk(spec, feed, chunk)
 	})
@@ -63,7 +63,7 @@ func (mt *multitracker) TrackJob(kube kubernetes.Interface, spec MultitrackSpec,
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.JobsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.JobsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.jobPodError(spec, feed, podError)
 	})
@@ -71,7 +71,11 @@ func (mt *multitracker) TrackJob(kube kubernetes.Interface, spec MultitrackSpec,
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.JobsStatuses[spec.ResourceName] = status
+		mt.JobsStatuses[resourceKey(spec)] = status
+
+		if !status.IsFailed && !hasPodsErrors(status.Pods, podsNames(status.Pods)) {
+			mt.resetResourceFailure(mt.TrackingJobs, spec)
+		}
 
 		return nil
 	})
@@ -80,19 +84,24 @@ func (mt *multitracker) TrackJob(kube kubernetes.Interface, spec MultitrackSpec,
 }
 
 func (mt *multitracker) jobAdded(spec MultitrackSpec, feed job.Feed) error {
+	mt.handleResourceAdded(mt.TrackingJobs, spec)
+
 	mt.displayResourceTrackerMessageF("job", spec, "added")
+	mt.emitResourceEvent("job", spec, Event{Type: ResourceAddedEvent})
 
 	return nil
 }
 
 func (mt *multitracker) jobSucceeded(spec MultitrackSpec, feed job.Feed) error {
 	mt.displayResourceTrackerMessageF("job", spec, "succeeded")
+	mt.emitResourceEvent("job", spec, Event{Type: ResourceReadyEvent})
 
 	return mt.handleResourceReadyCondition(mt.TrackingJobs, spec)
 }
 
 func (mt *multitracker) jobFailed(spec MultitrackSpec, feed job.Feed, reason string) error {
 	mt.displayResourceErrorF("job", spec, "%s", reason)
+	mt.emitResourceEvent("job", spec, Event{Type: ResourceFailedEvent, Message: reason})
 	return mt.handleResourceFailure(mt.TrackingJobs, "job", spec, reason)
 }
 
@@ -103,18 +112,26 @@ func (mt *multitracker) jobEventMsg(spec MultitrackSpec, feed job.Feed, msg stri
 
 func (mt *multitracker) jobAddedPod(spec MultitrackSpec, feed job.Feed, podName string) error {
 	mt.displayResourceTrackerMessageF("job", spec, "po/%s added", podName)
+	mt.emitResourceEvent("job", spec, Event{Type: PodAddedEvent, Pod: podName})
 	return nil
 }
 
 func (mt *multitracker) jobPodLogChunk(spec MultitrackSpec, feed job.Feed, chunk *pod.PodLogChunk) error {
-	mt.displayResourceLogChunk("job", spec, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk), chunk.ContainerLogChunk)
+	status := mt.JobsStatuses[resourceKey(spec)]
+	if !isPodLogsVisible(spec, status.Pods, chunk.PodName) {
+		return nil
+	}
+
+	mt.displayResourceLogChunk("job", spec, chunk.PodName, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk), chunk.ContainerLogChunk)
 	return nil
 }
 
 func (mt *multitracker) jobPodError(spec MultitrackSpec, feed job.Feed, podError pod.PodError) error {
 	reason := fmt.Sprintf("po/%s container/%s: %s", podError.PodName, podError.ContainerName, podError.Message)
+	reason = withContainerFailureHint(reason, mt.JobsStatuses[resourceKey(spec)].Pods, podError.PodName, podError.ContainerName)
 
 	mt.displayResourceErrorF("job", spec, "%s", reason)
+	mt.emitResourceEvent("job", spec, Event{Type: ContainerErrorEvent, Pod: podError.PodName, Container: podError.ContainerName, Message: podError.Message})
 
 	return mt.handleResourceFailure(mt.TrackingJobs, "job", spec, reason)
 }
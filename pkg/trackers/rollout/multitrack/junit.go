@@ -0,0 +1,193 @@
+package multitrack
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReport writes the report with a test suite for each namespace and a test case for each tracked resource.
+// Duration of the test case is the time from the resource is added to the resource is ready (or to the end of tracking).
+// Resources which are not done when tracking is stopped by the trackingErr are reported as failures.
+func (mt *multitracker) writeJUnitReport(path string, trackingErr error) error {
+	mt.mux.Lock()
+	defer mt.mux.Unlock()
+
+	report := junitTestSuites{Name: "multitrack"}
+
+	now := time.Now()
+	startedAt := now
+	suites := make(map[string]*junitTestSuite)
+	suitesStartedAt := make(map[string]time.Time)
+
+	for _, k := range mt.trackedKinds() {
+		for name, state := range k.States {
+			spec := k.Specs[name]
+			kind := k.ResourceKind(spec)
+
+			suite, hasKey := suites[spec.Namespace]
+			if !hasKey {
+				suite = &junitTestSuite{Name: spec.Namespace}
+				suites[spec.Namespace] = suite
+				suitesStartedAt[spec.Namespace] = state.TrackingStartedAt
+			}
+			if state.TrackingStartedAt.Before(suitesStartedAt[spec.Namespace]) {
+				suitesStartedAt[spec.Namespace] = state.TrackingStartedAt
+			}
+			if state.TrackingStartedAt.Before(startedAt) {
+				startedAt = state.TrackingStartedAt
+			}
+
+			testCase := junitTestCase{
+				Name:      fmt.Sprintf("%s/%s", kind, spec.ResourceName),
+				ClassName: fmt.Sprintf("%s.%s", spec.Namespace, kind),
+				Time:      junitDuration(resourceDuration(state, now)),
+			}
+
+			switch state.Status {
+			case resourceSucceeded:
+
+			case resourceFailed, resourceTimedOut:
+				testCase.Failure = &junitMessage{Message: state.FailedReason, Type: strings.TrimPrefix(string(state.Status), "resource"), Text: state.FailedReason}
+				suite.Failures++
+
+			default:
+				// Tracking has been interrupted by the failure of other resource or by the timeout
+				message := mt.resourceNotDoneMessage(k, spec, trackingErr)
+				if isResourceNotDoneOnError(spec, state, trackingErr) {
+					testCase.Failure = &junitMessage{Message: message, Type: strings.TrimPrefix(string(state.Status), "resource"), Text: message}
+					suite.Failures++
+				} else {
+					testCase.Skipped = &junitMessage{Message: message}
+					suite.Skipped++
+				}
+			}
+
+			if lines := mt.serviceMessagesByResource[resourceID(kind, spec)]; len(lines) > 0 {
+				testCase.SystemOut = strings.Join(lines, "\n") + "\n"
+			}
+
+			suite.Cases = append(suite.Cases, testCase)
+			suite.Tests++
+		}
+	}
+
+	var namespaces []string
+	for namespace := range suites {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		suite := suites[namespace]
+		sort.Slice(suite.Cases, func(i, j int) bool {
+			return suite.Cases[i].Name < suite.Cases[j].Name
+		})
+		suite.Time = junitDuration(now.Sub(suitesStartedAt[namespace]))
+		suite.Timestamp = suitesStartedAt[namespace].UTC().Format("2006-01-02T15:04:05")
+
+		report.Suites = append(report.Suites, *suite)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Skipped += suite.Skipped
+	}
+	report.Time = junitDuration(now.Sub(startedAt))
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append([]byte(xml.Header), append(data, '\n')...), 0644)
+}
+
+// resourceNotDoneMessage describes the resource which is not done when tracking has been stopped
+func (mt *multitracker) resourceNotDoneMessage(k multitrackerKind, spec MultitrackSpec, trackingErr error) string {
+	state := k.States[resourceKey(spec)]
+
+	message := "tracking stopped before the resource is ready"
+	if waitingForMessages := mt.resourceWaitingForMessages(k, spec); len(waitingForMessages) > 0 {
+		message = fmt.Sprintf("%s, waiting for: %s", message, strings.Join(waitingForMessages, ", "))
+	}
+	if state.PendingFailureReason != "" {
+		message = fmt.Sprintf("%s, last error: %s", message, state.PendingFailureReason)
+	}
+	if trackingErr != nil {
+		message = fmt.Sprintf("%s, tracking error: %s", message, trackingErr)
+	}
+
+	return message
+}
+
+// isResourceNotDoneOnError returns true for the blocking resource, which is not done when tracking is stopped by the error,
+// for example by the overall timeout
+func isResourceNotDoneOnError(spec MultitrackSpec, state *multitrackerResourceState, trackingErr error) bool {
+	if trackingErr == nil || spec.TrackTerminationMode == NonBlocking {
+		return false
+	}
+
+	switch state.Status {
+	case resourceSucceeded, resourceFailed, resourceTimedOut:
+		return false
+	}
+
+	return true
+}
+
+// resourceDuration returns the time from the resource is added to the resource is ready,
+// tracking start and end are used for resources which have not been added or have not become ready.
+func resourceDuration(state *multitrackerResourceState, now time.Time) time.Duration {
+	from := state.AddedAt
+	if from.IsZero() {
+		from = state.TrackingStartedAt
+	}
+
+	to := state.ReadyAt
+	if to.IsZero() {
+		to = now
+	}
+
+	return to.Sub(from)
+}
+
+func junitDuration(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
@@ -0,0 +1,289 @@
+package multitrack
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Annotations of the resources in manifests to set the options of the resource spec
+const (
+	TrackTerminationModeAnnotation      = "kubedog.io/track-termination-mode"
+	FailModeAnnotation                  = "kubedog.io/fail-mode"
+	AllowFailuresCountAnnotation        = "kubedog.io/allow-failures-count"
+	FailureThresholdSecondsAnnotation   = "kubedog.io/failure-threshold-seconds"
+	ReadyStabilitySecondsAnnotation     = "kubedog.io/ready-stability-seconds"
+	TimeoutSecondsAnnotation            = "kubedog.io/timeout-seconds"
+	LogRegexAnnotation                  = "kubedog.io/log-regex"
+	SkipLogsAnnotation                  = "kubedog.io/skip-logs"
+	SkipLogsForContainersAnnotation     = "kubedog.io/skip-logs-for-containers"
+	ShowLogsOnlyForContainersAnnotation = "kubedog.io/show-logs-only-for-containers"
+	ShowLogsUntilAnnotation             = "kubedog.io/show-logs-until"
+	ShowServiceMessagesAnnotation       = "kubedog.io/show-service-messages"
+	DependsOnAnnotation                 = "kubedog.io/depends-on"
+)
+
+// ReadManifestsSpecs makes specs from the manifests file or from all yaml and json files of the directory (recursively).
+// Resources without namespace are considered to be in the defaultNamespace.
+func ReadManifestsSpecs(path string, defaultNamespace string) (MultitrackSpecs, error) {
+	var files []string
+
+	err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		if filePath == path {
+			// File specified explicitly is read regardless of the extension
+			files = append(files, filePath)
+			return nil
+		}
+
+		switch strings.ToLower(filepath.Ext(filePath)) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, filePath)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return MultitrackSpecs{}, err
+	}
+
+	sort.Strings(files)
+
+	specs := MultitrackSpecs{}
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return MultitrackSpecs{}, err
+		}
+
+		if err := parseManifests(&specs, bytes.NewReader(content), defaultNamespace); err != nil {
+			return MultitrackSpecs{}, fmt.Errorf("%s: %s", file, err)
+		}
+	}
+
+	return specs, nil
+}
+
+// ParseManifestsSpecs makes specs from the multi-document yaml (or json) manifests.
+// Only resources of the trackable kinds are picked, options of the spec are read from kubedog.io/* annotations of the resource.
+func ParseManifestsSpecs(r io.Reader, defaultNamespace string) (MultitrackSpecs, error) {
+	specs := MultitrackSpecs{}
+	if err := parseManifests(&specs, r, defaultNamespace); err != nil {
+		return MultitrackSpecs{}, err
+	}
+	return specs, nil
+}
+
+func parseManifests(specs *MultitrackSpecs, r io.Reader, defaultNamespace string) error {
+	decoder := yaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("unable to parse manifests: %s", err)
+		}
+
+		if len(obj.Object) == 0 {
+			// Empty document
+			continue
+		}
+
+		if obj.IsList() {
+			err := obj.EachListItem(func(item runtime.Object) error {
+				return addManifestSpec(specs, item.(*unstructured.Unstructured), defaultNamespace)
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := addManifestSpec(specs, obj, defaultNamespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addManifestSpec(specs *MultitrackSpecs, obj *unstructured.Unstructured, defaultNamespace string) error {
+	var kindSpecs *[]MultitrackSpec
+
+	switch obj.GetKind() {
+	case "Deployment":
+		kindSpecs = &specs.Deployments
+	case "StatefulSet":
+		kindSpecs = &specs.StatefulSets
+	case "DaemonSet":
+		kindSpecs = &specs.DaemonSets
+	case "Job":
+		kindSpecs = &specs.Jobs
+	case "CronJob":
+		kindSpecs = &specs.CronJobs
+	case "Pod":
+		kindSpecs = &specs.Pods
+	case "Service":
+		kindSpecs = &specs.Services
+	case "Ingress":
+		kindSpecs = &specs.Ingresses
+	case "PersistentVolumeClaim":
+		kindSpecs = &specs.PersistentVolumeClaims
+	case "Rollout":
+		if !strings.HasPrefix(obj.GetAPIVersion(), "argoproj.io/") {
+			return nil
+		}
+		kindSpecs = &specs.Rollouts
+	default:
+		return nil
+	}
+
+	if obj.GetName() == "" {
+		return fmt.Errorf("%s without metadata.name in manifests", obj.GetKind())
+	}
+
+	spec := MultitrackSpec{
+		ResourceName: obj.GetName(),
+		Namespace:    obj.GetNamespace(),
+	}
+	if spec.Namespace == "" {
+		spec.Namespace = defaultNamespace
+	}
+
+	if err := setSpecAnnotationsValues(&spec, obj.GetAnnotations()); err != nil {
+		return fmt.Errorf("%s/%s: %s", strings.ToLower(obj.GetKind()), obj.GetName(), err)
+	}
+
+	*kindSpecs = append(*kindSpecs, spec)
+
+	return nil
+}
+
+func setSpecAnnotationsValues(spec *MultitrackSpec, annotations map[string]string) error {
+	for name, value := range annotations {
+		value = strings.TrimSpace(value)
+
+		switch name {
+		case TrackTerminationModeAnnotation:
+			switch mode := TrackTerminationMode(value); mode {
+			case WaitUntilResourceReady, NonBlocking, WaitUntilResourceDeleted:
+				spec.TrackTerminationMode = mode
+			default:
+				return fmt.Errorf("bad %s annotation value %q", name, value)
+			}
+
+		case FailModeAnnotation:
+			switch mode := FailMode(value); mode {
+			case IgnoreAndContinueDeployProcess, FailWholeDeployProcessImmediately, HopeUntilEndOfDeployProcess:
+				spec.FailMode = mode
+			default:
+				return fmt.Errorf("bad %s annotation value %q", name, value)
+			}
+
+		case AllowFailuresCountAnnotation:
+			count, err := annotationIntValue(name, value)
+			if err != nil {
+				return err
+			}
+			spec.AllowFailuresCount = count
+
+		case FailureThresholdSecondsAnnotation:
+			seconds, err := annotationIntValue(name, value)
+			if err != nil {
+				return err
+			}
+			spec.FailureThresholdSeconds = seconds
+
+		case ReadyStabilitySecondsAnnotation:
+			seconds, err := annotationIntValue(name, value)
+			if err != nil {
+				return err
+			}
+			spec.ReadyStabilitySeconds = seconds
+
+		case TimeoutSecondsAnnotation:
+			seconds, err := annotationIntValue(name, value)
+			if err != nil {
+				return err
+			}
+			spec.TimeoutSeconds = seconds
+
+		case LogRegexAnnotation:
+			logRegex, err := regexp.Compile(value)
+			if err != nil {
+				return fmt.Errorf("bad %s annotation value %q: %s", name, value, err)
+			}
+			spec.LogRegex = logRegex
+
+		case SkipLogsAnnotation:
+			skipLogs, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("bad %s annotation value %q: boolean expected", name, value)
+			}
+			spec.SkipLogs = skipLogs
+
+		case SkipLogsForContainersAnnotation:
+			spec.SkipLogsForContainers = annotationListValue(value)
+
+		case ShowLogsOnlyForContainersAnnotation:
+			spec.ShowLogsOnlyForContainers = annotationListValue(value)
+
+		case ShowLogsUntilAnnotation:
+			switch condition := DeployCondition(value); condition {
+			case ControllerIsReady, PodIsReady, EndOfDeploy:
+				spec.ShowLogsUntil = condition
+			default:
+				return fmt.Errorf("bad %s annotation value %q", name, value)
+			}
+
+		case ShowServiceMessagesAnnotation:
+			showServiceMessages, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("bad %s annotation value %q: boolean expected", name, value)
+			}
+			spec.ShowServiceMessages = showServiceMessages
+
+		case DependsOnAnnotation:
+			spec.DependsOn = annotationListValue(value)
+		}
+	}
+
+	return nil
+}
+
+func annotationIntValue(name, value string) (*int, error) {
+	res, err := strconv.Atoi(value)
+	if err != nil || res < 0 {
+		return nil, fmt.Errorf("bad %s annotation value %q: non-negative integer expected", name, value)
+	}
+	return &res, nil
+}
+
+// annotationListValue parses comma separated list
+func annotationListValue(value string) []string {
+	var res []string
+	for _, elem := range strings.Split(value, ",") {
+		if elem = strings.TrimSpace(elem); elem != "" {
+			res = append(res, elem)
+		}
+	}
+	return res
+}
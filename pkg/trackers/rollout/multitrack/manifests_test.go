@@ -0,0 +1,294 @@
+package multitrack
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseManifestsSpecs(t *testing.T) {
+	manifests := `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  annotations:
+    kubedog.io/fail-mode: HopeUntilEndOfDeployProcess
+    kubedog.io/allow-failures-count: "3"
+    kubedog.io/skip-logs-for-containers: "sidecar, init ,"
+    kubedog.io/depends-on: job/migrate
+---
+# empty document
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  namespace: otherns
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: web
+- apiVersion: v1
+  kind: PersistentVolumeClaim
+  metadata:
+    name: data
+---
+apiVersion: argoproj.io/v1alpha1
+kind: Rollout
+metadata:
+  name: canary
+---
+apiVersion: other.io/v1
+kind: Rollout
+metadata:
+  name: other
+---
+{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "debug"}}
+`
+
+	specs, err := ParseManifestsSpecs(strings.NewReader(manifests), "myns")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	names := func(kindSpecs []MultitrackSpec) []string {
+		var res []string
+		for _, spec := range kindSpecs {
+			res = append(res, spec.Namespace+"/"+spec.ResourceName)
+		}
+		return res
+	}
+
+	for _, tt := range []struct {
+		kind  string
+		specs []MultitrackSpec
+		want  []string
+	}{
+		{"Deployments", specs.Deployments, []string{"myns/app"}},
+		{"Jobs", specs.Jobs, []string{"otherns/migrate"}},
+		{"Services", specs.Services, []string{"myns/web"}},
+		{"PersistentVolumeClaims", specs.PersistentVolumeClaims, []string{"myns/data"}},
+		{"Rollouts", specs.Rollouts, []string{"myns/canary"}},
+		{"Pods", specs.Pods, []string{"myns/debug"}},
+		{"StatefulSets", specs.StatefulSets, nil},
+	} {
+		if got := names(tt.specs); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.kind, tt.want, got)
+		}
+	}
+
+	deploySpec := specs.Deployments[0]
+	if deploySpec.FailMode != HopeUntilEndOfDeployProcess {
+		t.Errorf("expected FailMode %q, got %q", HopeUntilEndOfDeployProcess, deploySpec.FailMode)
+	}
+	if deploySpec.AllowFailuresCount == nil || *deploySpec.AllowFailuresCount != 3 {
+		t.Errorf("expected AllowFailuresCount 3, got %v", deploySpec.AllowFailuresCount)
+	}
+	if want := []string{"sidecar", "init"}; !reflect.DeepEqual(deploySpec.SkipLogsForContainers, want) {
+		t.Errorf("expected SkipLogsForContainers %v, got %v", want, deploySpec.SkipLogsForContainers)
+	}
+	if want := []string{"job/migrate"}; !reflect.DeepEqual(deploySpec.DependsOn, want) {
+		t.Errorf("expected DependsOn %v, got %v", want, deploySpec.DependsOn)
+	}
+}
+
+func TestParseManifestsSpecsErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifests string
+		wantErr   string
+	}{
+		{
+			name:      "without name",
+			manifests: "apiVersion: apps/v1\nkind: Deployment\nmetadata: {}\n",
+			wantErr:   "Deployment without metadata.name in manifests",
+		},
+		{
+			name:      "bad annotation",
+			manifests: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: app\n  annotations:\n    kubedog.io/skip-logs: maybe\n",
+			wantErr:   `deployment/app: bad kubedog.io/skip-logs annotation value "maybe": boolean expected`,
+		},
+		{
+			name:      "bad yaml",
+			manifests: "apiVersion: apps/v1\nkind: [Deployment\n",
+			wantErr:   "unable to parse manifests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseManifestsSpecs(strings.NewReader(tt.manifests), "myns")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSetSpecAnnotationsValues(t *testing.T) {
+	intPtr := func(value int) *int {
+		return &value
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		check       func(spec MultitrackSpec) bool
+		wantErr     string
+	}{
+		{
+			name:        "track termination mode",
+			annotations: map[string]string{TrackTerminationModeAnnotation: " NonBlocking "},
+			check:       func(spec MultitrackSpec) bool { return spec.TrackTerminationMode == NonBlocking },
+		},
+		{
+			name:        "bad track termination mode",
+			annotations: map[string]string{TrackTerminationModeAnnotation: "Never"},
+			wantErr:     `bad kubedog.io/track-termination-mode annotation value "Never"`,
+		},
+		{
+			name:        "bad fail mode",
+			annotations: map[string]string{FailModeAnnotation: ""},
+			wantErr:     "bad kubedog.io/fail-mode annotation value",
+		},
+		{
+			name:        "seconds",
+			annotations: map[string]string{FailureThresholdSecondsAnnotation: "10", ReadyStabilitySecondsAnnotation: "0", TimeoutSecondsAnnotation: "300"},
+			check: func(spec MultitrackSpec) bool {
+				return reflect.DeepEqual(spec.FailureThresholdSeconds, intPtr(10)) &&
+					reflect.DeepEqual(spec.ReadyStabilitySeconds, intPtr(0)) &&
+					reflect.DeepEqual(spec.TimeoutSeconds, intPtr(300))
+			},
+		},
+		{
+			name:        "negative integer",
+			annotations: map[string]string{AllowFailuresCountAnnotation: "-1"},
+			wantErr:     "non-negative integer expected",
+		},
+		{
+			name:        "not an integer",
+			annotations: map[string]string{TimeoutSecondsAnnotation: "5m"},
+			wantErr:     "non-negative integer expected",
+		},
+		{
+			name:        "log regex",
+			annotations: map[string]string{LogRegexAnnotation: "(ERROR|WARN)"},
+			check:       func(spec MultitrackSpec) bool { return spec.LogRegex != nil && spec.LogRegex.MatchString("WARN: x") },
+		},
+		{
+			name:        "bad log regex",
+			annotations: map[string]string{LogRegexAnnotation: "(ERROR"},
+			wantErr:     "bad kubedog.io/log-regex annotation value",
+		},
+		{
+			name:        "booleans",
+			annotations: map[string]string{SkipLogsAnnotation: "true", ShowServiceMessagesAnnotation: "1"},
+			check:       func(spec MultitrackSpec) bool { return spec.SkipLogs && spec.ShowServiceMessages },
+		},
+		{
+			name:        "show logs until",
+			annotations: map[string]string{ShowLogsUntilAnnotation: "EndOfDeploy"},
+			check:       func(spec MultitrackSpec) bool { return spec.ShowLogsUntil == EndOfDeploy },
+		},
+		{
+			name:        "bad show logs until",
+			annotations: map[string]string{ShowLogsUntilAnnotation: "Forever"},
+			wantErr:     "bad kubedog.io/show-logs-until annotation value",
+		},
+		{
+			name:        "empty list",
+			annotations: map[string]string{ShowLogsOnlyForContainersAnnotation: " , "},
+			check:       func(spec MultitrackSpec) bool { return spec.ShowLogsOnlyForContainers == nil },
+		},
+		{
+			name:        "unknown annotations are ignored",
+			annotations: map[string]string{"kubedog.io/unknown": "value", "app.kubernetes.io/name": "app"},
+			check:       func(spec MultitrackSpec) bool { return reflect.DeepEqual(spec, MultitrackSpec{}) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := MultitrackSpec{}
+			err := setSpecAnnotationsValues(&spec, tt.annotations)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !tt.check(spec) {
+				t.Fatalf("unexpected spec %+v", spec)
+			}
+		})
+	}
+}
+
+func TestReadManifestsSpecs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubedog-manifests")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"b.yaml":        "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: b\n",
+		"a/a.yml":       "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: a\n",
+		"c.json":        `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "c"}}`,
+		"README.md":     "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: readme\n",
+		"manifest.tmpl": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: tmpl\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{name: "directory", path: dir, want: []string{"a", "b", "c"}},
+		{name: "file with any extension", path: filepath.Join(dir, "manifest.tmpl"), want: []string{"tmpl"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs, err := ReadManifestsSpecs(tt.path, "myns")
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var got []string
+			for _, spec := range specs.Deployments {
+				got = append(got, spec.ResourceName)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -11,12 +11,22 @@ import (
 
 	"github.com/flant/logboek"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/flant/kubedog/pkg/tracker"
+	"github.com/flant/kubedog/pkg/tracker/argorollout"
+	"github.com/flant/kubedog/pkg/tracker/cronjob"
 	"github.com/flant/kubedog/pkg/tracker/daemonset"
+	"github.com/flant/kubedog/pkg/tracker/deletion"
 	"github.com/flant/kubedog/pkg/tracker/deployment"
+	"github.com/flant/kubedog/pkg/tracker/generic"
+	"github.com/flant/kubedog/pkg/tracker/ingress"
 	"github.com/flant/kubedog/pkg/tracker/job"
+	"github.com/flant/kubedog/pkg/tracker/pod"
+	"github.com/flant/kubedog/pkg/tracker/pvc"
+	"github.com/flant/kubedog/pkg/tracker/service"
 	"github.com/flant/kubedog/pkg/tracker/statefulset"
 )
 
@@ -25,6 +35,8 @@ type TrackTerminationMode string
 const (
 	WaitUntilResourceReady TrackTerminationMode = "WaitUntilResourceReady"
 	NonBlocking            TrackTerminationMode = "NonBlocking"
+	// WaitUntilResourceDeleted waits until the resource and its Pods are gone
+	WaitUntilResourceDeleted TrackTerminationMode = "WaitUntilResourceDeleted"
 )
 
 type FailMode string
@@ -35,13 +47,13 @@ const (
 	HopeUntilEndOfDeployProcess       FailMode = "HopeUntilEndOfDeployProcess"
 )
 
-//type DeployCondition string
-//
-//const (
-//	ControllerIsReady DeployCondition = "ControllerIsReady"
-//	PodIsReady        DeployCondition = "PodIsReady"
-//	EndOfDeploy       DeployCondition = "EndOfDeploy"
-//)
+type DeployCondition string
+
+const (
+	ControllerIsReady DeployCondition = "ControllerIsReady"
+	PodIsReady        DeployCondition = "PodIsReady"
+	EndOfDeploy       DeployCondition = "EndOfDeploy"
+)
 
 var (
 	ErrFailWholeDeployProcessImmediately = errors.New("fail whole deploy process immediately")
@@ -52,16 +64,42 @@ type MultitrackSpecs struct {
 	StatefulSets []MultitrackSpec
 	DaemonSets   []MultitrackSpec
 	Jobs         []MultitrackSpec
+	CronJobs     []MultitrackSpec
+	Pods         []MultitrackSpec
+	Services     []MultitrackSpec
+	Ingresses    []MultitrackSpec
+
+	PersistentVolumeClaims []MultitrackSpec
+
+	// Rollouts are Argo Rollouts (argoproj.io/v1alpha1)
+	Rollouts []MultitrackSpec
+
+	Generic []MultitrackSpec
+
+	// Selectors are expanded into all Deployments, StatefulSets, DaemonSets and Jobs matched by the LabelSelector,
+	// including resources created during tracking. Kind limits matched resources to the single kind.
+	Selectors []MultitrackSpec
 }
 
 type MultitrackSpec struct {
 	ResourceName string
 	Namespace    string
 
+	// LabelSelector is used only for the Selectors in place of the ResourceName, for example `app=myapp,tier!=db`
+	LabelSelector string
+
 	TrackTerminationMode    TrackTerminationMode
 	FailMode                FailMode
 	AllowFailuresCount      *int
 	FailureThresholdSeconds *int
+	// ReadyStabilitySeconds is the period the resource should stay ready without pods restarts
+	// after the first ready condition before it is considered ready, 0 by default.
+	// Not used for the Jobs and CronJobs.
+	ReadyStabilitySeconds *int
+	// TimeoutSeconds is the time to wait for the resource, 0 (default) means no timeout.
+	// The resource fails accordingly to the FailMode when the timeout is exceeded,
+	// AllowFailuresCount is not applied to the timeout. MultitrackOptions.Timeout remains the overall limit.
+	TimeoutSeconds *int
 
 	LogRegex                *regexp.Regexp
 	LogRegexByContainerName map[string]*regexp.Regexp
@@ -69,14 +107,57 @@ type MultitrackSpec struct {
 	SkipLogs                  bool
 	SkipLogsForContainers     []string
 	ShowLogsOnlyForContainers []string
-	//ShowLogsUntil             DeployCondition TODO
+	ShowLogsUntil             DeployCondition
 
 	ShowServiceMessages bool
+
+	DependsOn                      []string
+	SkipLogsUntilDependenciesReady bool
+
+	// WaitForNextRun is used only for the CronJobs: wait until the next Job spawned by the CronJob succeeds
+	// instead of checking that the CronJob exists and is not suspended.
+	WaitForNextRun bool
+
+	// MinReadyEndpoints and UseEndpointSlices are used only for the Services.
+	// Service is ready when the LoadBalancer has an ingress ip or hostname (for the LoadBalancer Services)
+	// and there are at least MinReadyEndpoints ready addresses (1 by default for the Services with selector).
+	MinReadyEndpoints *int
+	UseEndpointSlices bool
+
+	// Kind is Deployment, StatefulSet, DaemonSet or Job for the Selectors.
+	// Kind, APIVersion and readiness rules are used only for the Generic resources.
+	// APIVersion is optional, the preferred version of the Kind is used by default.
+	// Generic resource is ready when all ReadyConditions and ReadyJSONPaths are matched
+	// (condition Ready=True by default) and failed when any of FailedConditions or FailedJSONPaths is matched.
+	// JSONPath rules are expressions such as `.status.phase == "Bound"` or `.status.readyReplicas >= .spec.replicas`.
+	Kind             string
+	APIVersion       string
+	ReadyConditions  []generic.ConditionRule
+	FailedConditions []generic.ConditionRule
+	ReadyJSONPaths   []string
+	FailedJSONPaths  []string
 }
 
 type MultitrackOptions struct {
 	tracker.Options
 	StatusProgressPeriod time.Duration
+
+	// DynamicClient is required to track Generic resources and Rollouts
+	DynamicClient dynamic.Interface
+
+	// EventSink receives machine-readable events in addition to the displayed messages
+	EventSink EventSink
+
+	// JUnitReportFile is the path of the JUnit XML report with a test case for each tracked resource,
+	// the report is written when tracking is done (successfully or not)
+	JUnitReportFile string
+
+	// DiagnosticsPath is the directory (or the .tar.gz file) to save diagnostics of the failed resources to when tracking is failed:
+	// objects, events, pods and last DiagnosticsLogLines (100 by default) of the containers logs, including previous logs of restarted containers.
+	// Resources which are not done when tracking is stopped with an error (such as the overall timeout) are treated as failed.
+	// DynamicClient is required to gather diagnostics.
+	DiagnosticsPath     string
+	DiagnosticsLogLines int
 }
 
 func newMultitrackOptions(parentContext context.Context, timeout, statusProgessPeriod time.Duration, logsFromTime time.Time) MultitrackOptions {
@@ -108,11 +189,61 @@ func setDefaultSpecValues(spec *MultitrackSpec) {
 		spec.FailureThresholdSeconds = new(int)
 		*spec.FailureThresholdSeconds = 0
 	}
+
+	if spec.ReadyStabilitySeconds == nil {
+		spec.ReadyStabilitySeconds = new(int)
+		*spec.ReadyStabilitySeconds = 0
+	}
+
+	if spec.TimeoutSeconds == nil {
+		spec.TimeoutSeconds = new(int)
+		*spec.TimeoutSeconds = 0
+	}
+
+	if spec.ShowLogsUntil == "" {
+		spec.ShowLogsUntil = PodIsReady
+	}
+}
+
+// validateSpecModes checks TrackTerminationMode, FailMode and ShowLogsUntil of the spec, empty values are allowed for defaults
+func validateSpecModes(spec MultitrackSpec) error {
+	switch spec.TrackTerminationMode {
+	case "", WaitUntilResourceReady, NonBlocking, WaitUntilResourceDeleted:
+	default:
+		return fmt.Errorf("bad TrackTerminationMode %q", spec.TrackTerminationMode)
+	}
+
+	switch spec.FailMode {
+	case "", IgnoreAndContinueDeployProcess, FailWholeDeployProcessImmediately, HopeUntilEndOfDeployProcess:
+	default:
+		return fmt.Errorf("bad FailMode %q", spec.FailMode)
+	}
+
+	switch spec.ShowLogsUntil {
+	case "", ControllerIsReady, PodIsReady, EndOfDeploy:
+	default:
+		return fmt.Errorf("bad ShowLogsUntil %q", spec.ShowLogsUntil)
+	}
+
+	return nil
 }
 
 func Multitrack(kube kubernetes.Interface, specs MultitrackSpecs, opts MultitrackOptions) error {
-	if len(specs.Deployments)+len(specs.StatefulSets)+len(specs.DaemonSets)+len(specs.Jobs) == 0 {
-		return nil
+	_, err := MultitrackWithResult(kube, specs, opts)
+	return err
+}
+
+// MultitrackWithResult is the same as Multitrack, but also returns the outcome of tracking of each resource,
+// which is available even if tracking is failed.
+func MultitrackWithResult(kube kubernetes.Interface, specs MultitrackSpecs, opts MultitrackOptions) (MultitrackResult, error) {
+	if len(specs.Deployments)+len(specs.StatefulSets)+len(specs.DaemonSets)+len(specs.Jobs)+len(specs.CronJobs)+len(specs.Pods)+len(specs.Services)+len(specs.Ingresses)+len(specs.PersistentVolumeClaims)+len(specs.Rollouts)+len(specs.Generic)+len(specs.Selectors) == 0 {
+		return MultitrackResult{}, nil
+	}
+
+	for _, s := range allSpecs(specs) {
+		if err := validateSpecModes(s.Spec); err != nil {
+			return MultitrackResult{}, fmt.Errorf("bad multitrack specs: %s/%s: %s", s.Kind, s.Spec.ResourceName, err)
+		}
 	}
 
 	for i := range specs.Deployments {
@@ -125,7 +256,75 @@ func Multitrack(kube kubernetes.Interface, specs MultitrackSpecs, opts Multitrac
 		setDefaultSpecValues(&specs.DaemonSets[i])
 	}
 	for i := range specs.Jobs {
+		// Job pods are never ready in the common sense, so show logs until the Job is done by default
+		if specs.Jobs[i].ShowLogsUntil == "" {
+			specs.Jobs[i].ShowLogsUntil = ControllerIsReady
+		}
 		setDefaultSpecValues(&specs.Jobs[i])
+		// Succeeded Job cannot become not ready again
+		specs.Jobs[i].ReadyStabilitySeconds = new(int)
+	}
+	for i := range specs.CronJobs {
+		// The same as for the Jobs, CronJob is ready only when its Job is done in WaitForNextRun mode
+		if specs.CronJobs[i].ShowLogsUntil == "" {
+			specs.CronJobs[i].ShowLogsUntil = ControllerIsReady
+		}
+		setDefaultSpecValues(&specs.CronJobs[i])
+		specs.CronJobs[i].ReadyStabilitySeconds = new(int)
+	}
+	for i := range specs.Pods {
+		// Standalone pod is the tracked resource itself, so show logs until it is ready or succeeded by default
+		if specs.Pods[i].ShowLogsUntil == "" {
+			specs.Pods[i].ShowLogsUntil = ControllerIsReady
+		}
+		setDefaultSpecValues(&specs.Pods[i])
+	}
+	for i := range specs.Services {
+		setDefaultSpecValues(&specs.Services[i])
+	}
+	for i := range specs.Ingresses {
+		setDefaultSpecValues(&specs.Ingresses[i])
+	}
+	for i := range specs.PersistentVolumeClaims {
+		setDefaultSpecValues(&specs.PersistentVolumeClaims[i])
+	}
+	for i := range specs.Rollouts {
+		setDefaultSpecValues(&specs.Rollouts[i])
+	}
+	for i := range specs.Generic {
+		if specs.Generic[i].Kind == "" {
+			return MultitrackResult{}, fmt.Errorf("bad multitrack specs: Kind is not specified for generic resource %q", specs.Generic[i].ResourceName)
+		}
+		if _, err := genericRules(specs.Generic[i]); err != nil {
+			return MultitrackResult{}, fmt.Errorf("bad multitrack specs: %s/%s: %s", genericResourceKind(specs.Generic[i]), specs.Generic[i].ResourceName, err)
+		}
+		setDefaultSpecValues(&specs.Generic[i])
+	}
+
+	for i := range specs.Selectors {
+		if err := validateSelectorSpec(specs.Selectors[i]); err != nil {
+			return MultitrackResult{}, fmt.Errorf("bad multitrack specs: selector %q: %s", specs.Selectors[i].LabelSelector, err)
+		}
+	}
+
+	if len(specs.Generic) > 0 && opts.DynamicClient == nil {
+		return MultitrackResult{}, fmt.Errorf("DynamicClient option is required to track generic resources")
+	}
+	if len(specs.Rollouts) > 0 && opts.DynamicClient == nil {
+		return MultitrackResult{}, fmt.Errorf("DynamicClient option is required to track rollouts")
+	}
+	if opts.DiagnosticsPath != "" && opts.DynamicClient == nil {
+		return MultitrackResult{}, fmt.Errorf("DynamicClient option is required to gather diagnostics")
+	}
+	for _, s := range allSpecs(specs) {
+		if s.Spec.TrackTerminationMode == WaitUntilResourceDeleted && opts.DynamicClient == nil {
+			return MultitrackResult{}, fmt.Errorf("DynamicClient option is required to wait for %s deletion", s.Kind)
+		}
+	}
+
+	dependencies, err := resolveSpecsDependencies(specs)
+	if err != nil {
+		return MultitrackResult{}, fmt.Errorf("bad multitrack specs: %s", err)
 	}
 
 	mt := multitracker{
@@ -153,7 +352,62 @@ func Multitrack(kube kubernetes.Interface, specs MultitrackSpecs, opts Multitrac
 		JobsStatuses:     make(map[string]job.JobStatus),
 		PrevJobsStatuses: make(map[string]job.JobStatus),
 
+		CronJobsSpecs:        make(map[string]MultitrackSpec),
+		CronJobsContexts:     make(map[string]*multitrackerContext),
+		TrackingCronJobs:     make(map[string]*multitrackerResourceState),
+		CronJobsStatuses:     make(map[string]cronjob.CronJobStatus),
+		PrevCronJobsStatuses: make(map[string]cronjob.CronJobStatus),
+
+		PodsSpecs:        make(map[string]MultitrackSpec),
+		PodsContexts:     make(map[string]*multitrackerContext),
+		TrackingPods:     make(map[string]*multitrackerResourceState),
+		PodsStatuses:     make(map[string]pod.PodStatus),
+		PrevPodsStatuses: make(map[string]pod.PodStatus),
+
+		ServicesSpecs:        make(map[string]MultitrackSpec),
+		ServicesContexts:     make(map[string]*multitrackerContext),
+		TrackingServices:     make(map[string]*multitrackerResourceState),
+		ServicesStatuses:     make(map[string]service.ServiceStatus),
+		PrevServicesStatuses: make(map[string]service.ServiceStatus),
+
+		IngressesSpecs:        make(map[string]MultitrackSpec),
+		IngressesContexts:     make(map[string]*multitrackerContext),
+		TrackingIngresses:     make(map[string]*multitrackerResourceState),
+		IngressesStatuses:     make(map[string]ingress.IngressStatus),
+		PrevIngressesStatuses: make(map[string]ingress.IngressStatus),
+
+		PVCsSpecs:        make(map[string]MultitrackSpec),
+		PVCsContexts:     make(map[string]*multitrackerContext),
+		TrackingPVCs:     make(map[string]*multitrackerResourceState),
+		PVCsStatuses:     make(map[string]pvc.PVCStatus),
+		PrevPVCsStatuses: make(map[string]pvc.PVCStatus),
+
+		RolloutsSpecs:        make(map[string]MultitrackSpec),
+		RolloutsContexts:     make(map[string]*multitrackerContext),
+		TrackingRollouts:     make(map[string]*multitrackerResourceState),
+		RolloutsStatuses:     make(map[string]argorollout.RolloutStatus),
+		PrevRolloutsStatuses: make(map[string]argorollout.RolloutStatus),
+
+		GenericSpecs:        make(map[string]MultitrackSpec),
+		GenericContexts:     make(map[string]*multitrackerContext),
+		TrackingGeneric:     make(map[string]*multitrackerResourceState),
+		GenericStatuses:     make(map[string]generic.GenericStatus),
+		PrevGenericStatuses: make(map[string]generic.GenericStatus),
+
+		DeletionsStatuses:     make(map[string]deletion.DeletionStatus),
+		PrevDeletionsStatuses: make(map[string]deletion.DeletionStatus),
+
+		SelectorsContexts: make(map[string]*multitrackerContext),
+		SyncedSelectors:   make(map[string]bool),
+
+		kube:          kube,
+		dynamicClient: opts.DynamicClient,
+		opts:          opts,
+
 		serviceMessagesByResource: make(map[string][]string),
+
+		isMultipleNamespaces: hasMultipleNamespaces(specs),
+		dependencies:         dependencies,
 	}
 
 	errorChan := make(chan error, 0)
@@ -161,6 +415,9 @@ func Multitrack(kube kubernetes.Interface, specs MultitrackSpecs, opts Multitrac
 
 	var statusProgressChan <-chan time.Time
 
+	pendingFailuresTicker := time.NewTicker(time.Second)
+	defer pendingFailuresTicker.Stop()
+
 	statusProgressPeriod := opts.StatusProgressPeriod
 	if opts.StatusProgressPeriod == 0 {
 		statusProgressPeriod = 5 * time.Second
@@ -180,22 +437,87 @@ func Multitrack(kube kubernetes.Interface, specs MultitrackSpecs, opts Multitrac
 		return mt.displayStatusProgress()
 	}
 
+	doCheckPendingFailures := func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+		return mt.checkPendingFailures()
+	}
+
+	doCheckReadyStability := func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+		return mt.checkReadyStability()
+	}
+
+	doCheckTimeouts := func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+		return mt.checkTimeouts()
+	}
+
 	mt.Start(kube, specs, doneChan, errorChan, opts)
 
-	for {
-		select {
-		case <-statusProgressChan:
-			if err := doDisplayStatusProgress(); err != nil {
+	err = func() error {
+		for {
+			select {
+			case <-statusProgressChan:
+				if err := doDisplayStatusProgress(); err != nil {
+					return err
+				}
+
+			case <-pendingFailuresTicker.C:
+				if err := doCheckPendingFailures(); err != nil {
+					return err
+				}
+				if err := doCheckReadyStability(); err != nil {
+					return err
+				}
+				if err := doCheckTimeouts(); err != nil {
+					return err
+				}
+
+			case <-doneChan:
+				return nil
+
+			case err := <-errorChan:
 				return err
 			}
+		}
+	}()
 
-		case <-doneChan:
-			return nil
+	result := func() MultitrackResult {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.displaySummary()
+
+		return mt.result()
+	}()
+
+	if err != nil && opts.DiagnosticsPath != "" {
+		if resourcesCount, diagnosticsErr := mt.writeDiagnostics(opts.DiagnosticsPath, opts.DiagnosticsLogLines, err); diagnosticsErr != nil {
+			mt.mux.Lock()
+			mt.displayMultitrackErrorMessageF("Unable to gather diagnostics: %s\n", diagnosticsErr)
+			mt.mux.Unlock()
+		} else if resourcesCount > 0 {
+			mt.mux.Lock()
+			mt.displayMultitrackServiceMessageF("Diagnostics of the failed resources are saved to %s\n", opts.DiagnosticsPath)
+			mt.mux.Unlock()
+		}
+	}
 
-		case err := <-errorChan:
-			return err
+	if opts.JUnitReportFile != "" {
+		if reportErr := mt.writeJUnitReport(opts.JUnitReportFile, err); reportErr != nil {
+			if err == nil {
+				return result, fmt.Errorf("unable to write junit report: %s", reportErr)
+			}
+			mt.mux.Lock()
+			mt.displayMultitrackErrorMessageF("Unable to write junit report: %s\n", reportErr)
+			mt.mux.Unlock()
 		}
 	}
+
+	return result, err
 }
 
 func (mt *multitracker) Start(kube kubernetes.Interface, specs MultitrackSpecs, doneChan chan struct{}, errorChan chan error, opts MultitrackOptions) {
@@ -205,53 +527,147 @@ func (mt *multitracker) Start(kube kubernetes.Interface, specs MultitrackSpecs,
 	var wg sync.WaitGroup
 
 	for _, spec := range specs.Deployments {
-		mt.DeploymentsContexts[spec.ResourceName] = newMultitrackerContext(opts.ParentContext)
-		mt.DeploymentsSpecs[spec.ResourceName] = spec
-		mt.TrackingDeployments[spec.ResourceName] = newMultitrackerResourceState(spec)
+		mt.DeploymentsContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.DeploymentsSpecs[resourceKey(spec)] = spec
+		mt.TrackingDeployments[resourceKey(spec)] = newMultitrackerResourceState(spec)
 
 		wg.Add(1)
 
-		go mt.runSpecTracker("deploy", spec, mt.DeploymentsContexts[spec.ResourceName], &wg, mt.DeploymentsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+		go mt.runSpecTracker("deploy", spec, mt.DeploymentsContexts[resourceKey(spec)], &wg, mt.DeploymentsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
 			return mt.TrackDeployment(kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
 		})
 	}
 
 	for _, spec := range specs.StatefulSets {
-		mt.StatefulSetsContexts[spec.ResourceName] = newMultitrackerContext(opts.ParentContext)
-		mt.StatefulSetsSpecs[spec.ResourceName] = spec
-		mt.TrackingStatefulSets[spec.ResourceName] = newMultitrackerResourceState(spec)
+		mt.StatefulSetsContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.StatefulSetsSpecs[resourceKey(spec)] = spec
+		mt.TrackingStatefulSets[resourceKey(spec)] = newMultitrackerResourceState(spec)
 
 		wg.Add(1)
 
-		go mt.runSpecTracker("sts", spec, mt.StatefulSetsContexts[spec.ResourceName], &wg, mt.StatefulSetsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+		go mt.runSpecTracker("sts", spec, mt.StatefulSetsContexts[resourceKey(spec)], &wg, mt.StatefulSetsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
 			return mt.TrackStatefulSet(kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
 		})
 	}
 
 	for _, spec := range specs.DaemonSets {
-		mt.DaemonSetsContexts[spec.ResourceName] = newMultitrackerContext(opts.ParentContext)
-		mt.DaemonSetsSpecs[spec.ResourceName] = spec
-		mt.TrackingDaemonSets[spec.ResourceName] = newMultitrackerResourceState(spec)
+		mt.DaemonSetsContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.DaemonSetsSpecs[resourceKey(spec)] = spec
+		mt.TrackingDaemonSets[resourceKey(spec)] = newMultitrackerResourceState(spec)
 
 		wg.Add(1)
 
-		go mt.runSpecTracker("ds", spec, mt.DaemonSetsContexts[spec.ResourceName], &wg, mt.DaemonSetsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+		go mt.runSpecTracker("ds", spec, mt.DaemonSetsContexts[resourceKey(spec)], &wg, mt.DaemonSetsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
 			return mt.TrackDaemonSet(kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
 		})
 	}
 
 	for _, spec := range specs.Jobs {
-		mt.JobsContexts[spec.ResourceName] = newMultitrackerContext(opts.ParentContext)
-		mt.JobsSpecs[spec.ResourceName] = spec
-		mt.TrackingJobs[spec.ResourceName] = newMultitrackerResourceState(spec)
+		mt.JobsContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.JobsSpecs[resourceKey(spec)] = spec
+		mt.TrackingJobs[resourceKey(spec)] = newMultitrackerResourceState(spec)
 
 		wg.Add(1)
 
-		go mt.runSpecTracker("job", spec, mt.JobsContexts[spec.ResourceName], &wg, mt.JobsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+		go mt.runSpecTracker("job", spec, mt.JobsContexts[resourceKey(spec)], &wg, mt.JobsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
 			return mt.TrackJob(kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
 		})
 	}
 
+	for _, spec := range specs.CronJobs {
+		mt.CronJobsContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.CronJobsSpecs[resourceKey(spec)] = spec
+		mt.TrackingCronJobs[resourceKey(spec)] = newMultitrackerResourceState(spec)
+
+		wg.Add(1)
+
+		go mt.runSpecTracker("cronjob", spec, mt.CronJobsContexts[resourceKey(spec)], &wg, mt.CronJobsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+			return mt.TrackCronJob(kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
+		})
+	}
+
+	for _, spec := range specs.Pods {
+		mt.PodsContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.PodsSpecs[resourceKey(spec)] = spec
+		mt.TrackingPods[resourceKey(spec)] = newMultitrackerResourceState(spec)
+
+		wg.Add(1)
+
+		go mt.runSpecTracker("po", spec, mt.PodsContexts[resourceKey(spec)], &wg, mt.PodsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+			return mt.TrackPod(kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
+		})
+	}
+
+	for _, spec := range specs.Services {
+		mt.ServicesContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.ServicesSpecs[resourceKey(spec)] = spec
+		mt.TrackingServices[resourceKey(spec)] = newMultitrackerResourceState(spec)
+
+		wg.Add(1)
+
+		go mt.runSpecTracker("svc", spec, mt.ServicesContexts[resourceKey(spec)], &wg, mt.ServicesContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+			return mt.TrackService(kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
+		})
+	}
+
+	for _, spec := range specs.Ingresses {
+		mt.IngressesContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.IngressesSpecs[resourceKey(spec)] = spec
+		mt.TrackingIngresses[resourceKey(spec)] = newMultitrackerResourceState(spec)
+
+		wg.Add(1)
+
+		go mt.runSpecTracker("ing", spec, mt.IngressesContexts[resourceKey(spec)], &wg, mt.IngressesContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+			return mt.TrackIngress(kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
+		})
+	}
+
+	for _, spec := range specs.PersistentVolumeClaims {
+		mt.PVCsContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.PVCsSpecs[resourceKey(spec)] = spec
+		mt.TrackingPVCs[resourceKey(spec)] = newMultitrackerResourceState(spec)
+
+		wg.Add(1)
+
+		go mt.runSpecTracker("pvc", spec, mt.PVCsContexts[resourceKey(spec)], &wg, mt.PVCsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+			return mt.TrackPVC(kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
+		})
+	}
+
+	for _, spec := range specs.Rollouts {
+		mt.RolloutsContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.RolloutsSpecs[resourceKey(spec)] = spec
+		mt.TrackingRollouts[resourceKey(spec)] = newMultitrackerResourceState(spec)
+
+		wg.Add(1)
+
+		go mt.runSpecTracker("rollout", spec, mt.RolloutsContexts[resourceKey(spec)], &wg, mt.RolloutsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+			return mt.TrackRollout(kube, opts.DynamicClient, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
+		})
+	}
+
+	for _, spec := range specs.Generic {
+		mt.GenericContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.GenericSpecs[resourceKey(spec)] = spec
+		mt.TrackingGeneric[resourceKey(spec)] = newMultitrackerResourceState(spec)
+
+		wg.Add(1)
+
+		go mt.runSpecTracker(genericResourceKind(spec), spec, mt.GenericContexts[resourceKey(spec)], &wg, mt.GenericContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+			return mt.TrackGeneric(kube, opts.DynamicClient, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
+		})
+	}
+
+	for _, spec := range specs.Selectors {
+		for _, kind := range selectorSpecKinds(spec) {
+			mt.SelectorsContexts[selectorID(kind, spec)] = newMultitrackerContext(opts.ParentContext)
+
+			wg.Add(1)
+
+			go mt.runSelectorTracker(kind, spec, mt.SelectorsContexts[selectorID(kind, spec)], &wg, doneChan, errorChan)
+		}
+	}
+
 	if err := mt.applyTrackTerminationMode(); err != nil {
 		errorChan <- fmt.Errorf("unable to apply termination mode: %s", err)
 		return
@@ -293,7 +709,12 @@ func (mt *multitracker) applyTrackTerminationMode() error {
 		return nil
 	}
 
-	shouldContinueTracking := func(name string, spec MultitrackSpec) bool {
+	shouldContinueTracking := func(name string, spec MultitrackSpec, state *multitrackerResourceState) bool {
+		if state.Status == resourceSucceeded {
+			// Resource is ready and tracked only to show logs until the end of deploy process
+			return false
+		}
+
 		switch spec.TrackTerminationMode {
 		case WaitUntilResourceReady:
 			// There is at least one active context with wait mode,
@@ -303,36 +724,34 @@ func (mt *multitracker) applyTrackTerminationMode() error {
 		case NonBlocking:
 			return false
 
+		case WaitUntilResourceDeleted:
+			return true
+
 		default:
 			panic(fmt.Sprintf("unknown TrackTerminationMode %#v", spec.TrackTerminationMode))
 		}
 	}
 
-	var contextsToStop []*multitrackerContext
-
-	for name, ctx := range mt.DeploymentsContexts {
-		if shouldContinueTracking(name, mt.DeploymentsSpecs[name]) {
+	for id := range mt.SelectorsContexts {
+		if !mt.SyncedSelectors[id] {
+			// Resources matched by the selector are not known yet
 			return nil
 		}
-		contextsToStop = append(contextsToStop, ctx)
 	}
-	for name, ctx := range mt.StatefulSetsContexts {
-		if shouldContinueTracking(name, mt.StatefulSetsSpecs[name]) {
-			return nil
-		}
-		contextsToStop = append(contextsToStop, ctx)
-	}
-	for name, ctx := range mt.DaemonSetsContexts {
-		if shouldContinueTracking(name, mt.DaemonSetsSpecs[name]) {
-			return nil
-		}
+
+	var contextsToStop []*multitrackerContext
+
+	for _, ctx := range mt.SelectorsContexts {
 		contextsToStop = append(contextsToStop, ctx)
 	}
-	for name, ctx := range mt.JobsContexts {
-		if shouldContinueTracking(name, mt.JobsSpecs[name]) {
-			return nil
+
+	for _, k := range mt.trackedKinds() {
+		for name, ctx := range k.Contexts {
+			if shouldContinueTracking(name, k.Specs[name], k.States[name]) {
+				return nil
+			}
+			contextsToStop = append(contextsToStop, ctx)
 		}
-		contextsToStop = append(contextsToStop, ctx)
 	}
 
 	mt.isTerminating = true
@@ -347,12 +766,17 @@ func (mt *multitracker) applyTrackTerminationMode() error {
 func (mt *multitracker) runSpecTracker(kind string, spec MultitrackSpec, mtCtx *multitrackerContext, wg *sync.WaitGroup, contexts map[string]*multitrackerContext, doneChan chan struct{}, errorChan chan error, trackerFunc func(MultitrackSpec, *multitrackerContext) error) {
 	defer wg.Done()
 
-	err := trackerFunc(spec, mtCtx)
+	var err error
+	if spec.TrackTerminationMode == WaitUntilResourceDeleted {
+		err = mt.TrackDeletion(kind, spec, mtCtx)
+	} else {
+		err = trackerFunc(spec, mtCtx)
+	}
 
 	mt.mux.Lock()
 	defer mt.mux.Unlock()
 
-	delete(contexts, spec.ResourceName)
+	delete(contexts, resourceKey(spec))
 
 	if err == ErrFailWholeDeployProcessImmediately {
 		mt.displayFailedTrackingResourcesServiceMessages()
@@ -363,7 +787,7 @@ func (mt *multitracker) runSpecTracker(kind string, spec MultitrackSpec, mtCtx *
 		return
 	} else if err != nil {
 		// unknown error
-		errorChan <- fmt.Errorf("%s/%s track failed: %s", kind, spec.ResourceName, err)
+		errorChan <- fmt.Errorf("%s track failed: %s", mt.fullResourceName(kind, spec), err)
 		mt.isFailed = true
 		return
 	}
@@ -400,17 +824,152 @@ type multitracker struct {
 	JobsStatuses     map[string]job.JobStatus
 	PrevJobsStatuses map[string]job.JobStatus
 
+	CronJobsSpecs        map[string]MultitrackSpec
+	CronJobsContexts     map[string]*multitrackerContext
+	TrackingCronJobs     map[string]*multitrackerResourceState
+	CronJobsStatuses     map[string]cronjob.CronJobStatus
+	PrevCronJobsStatuses map[string]cronjob.CronJobStatus
+
+	PodsSpecs        map[string]MultitrackSpec
+	PodsContexts     map[string]*multitrackerContext
+	TrackingPods     map[string]*multitrackerResourceState
+	PodsStatuses     map[string]pod.PodStatus
+	PrevPodsStatuses map[string]pod.PodStatus
+
+	ServicesSpecs        map[string]MultitrackSpec
+	ServicesContexts     map[string]*multitrackerContext
+	TrackingServices     map[string]*multitrackerResourceState
+	ServicesStatuses     map[string]service.ServiceStatus
+	PrevServicesStatuses map[string]service.ServiceStatus
+
+	IngressesSpecs        map[string]MultitrackSpec
+	IngressesContexts     map[string]*multitrackerContext
+	TrackingIngresses     map[string]*multitrackerResourceState
+	IngressesStatuses     map[string]ingress.IngressStatus
+	PrevIngressesStatuses map[string]ingress.IngressStatus
+
+	PVCsSpecs        map[string]MultitrackSpec
+	PVCsContexts     map[string]*multitrackerContext
+	TrackingPVCs     map[string]*multitrackerResourceState
+	PVCsStatuses     map[string]pvc.PVCStatus
+	PrevPVCsStatuses map[string]pvc.PVCStatus
+
+	RolloutsSpecs        map[string]MultitrackSpec
+	RolloutsContexts     map[string]*multitrackerContext
+	TrackingRollouts     map[string]*multitrackerResourceState
+	RolloutsStatuses     map[string]argorollout.RolloutStatus
+	PrevRolloutsStatuses map[string]argorollout.RolloutStatus
+
+	GenericSpecs        map[string]MultitrackSpec
+	GenericContexts     map[string]*multitrackerContext
+	TrackingGeneric     map[string]*multitrackerResourceState
+	GenericStatuses     map[string]generic.GenericStatus
+	PrevGenericStatuses map[string]generic.GenericStatus
+
+	// Statuses of the resources of any kind with WaitUntilResourceDeleted mode, map by resourceID
+	DeletionsStatuses     map[string]deletion.DeletionStatus
+	PrevDeletionsStatuses map[string]deletion.DeletionStatus
+
+	// Contexts of the selectors informers and selectors with already matched initial resources, map by selectorID
+	SelectorsContexts map[string]*multitrackerContext
+	SyncedSelectors   map[string]bool
+
+	// Clients and options are used by the deletion and selectors trackers, which start trackers on demand
+	kube          kubernetes.Interface
+	dynamicClient dynamic.Interface
+	opts          MultitrackOptions
+
 	mux sync.Mutex
 
 	isFailed      bool
 	isTerminating bool
 
+	// Namespace is shown in resources names only when resources from multiple namespaces are tracked
+	isMultipleNamespaces bool
+
+	// Resolved dependencies ids by the dependent resource id
+	dependencies map[string][]string
+
 	displayCalled             bool
 	currentLogProcessHeader   string
 	currentLogProcessOptions  logboek.LevelLogProcessStartOptions
 	serviceMessagesByResource map[string][]string
 }
 
+// resourceKey is a key of the resource in the multitracker maps, unique within resources of the same kind.
+// Generic resources of different kinds share the same maps, so the kind is a part of their key.
+func resourceKey(spec MultitrackSpec) string {
+	if spec.Kind != "" {
+		return fmt.Sprintf("%s/%s/%s", spec.Namespace, genericResourceKind(spec), spec.ResourceName)
+	}
+	return fmt.Sprintf("%s/%s", spec.Namespace, spec.ResourceName)
+}
+
+// resourceID is a unique identifier of the resource among all tracked resources
+func resourceID(kind string, spec MultitrackSpec) string {
+	return fmt.Sprintf("%s/%s/%s", spec.Namespace, kind, spec.ResourceName)
+}
+
+// fullResourceName returns resource name for the user messages: kind/name
+// or namespace/kind/name if resources from multiple namespaces are tracked
+func (mt *multitracker) fullResourceName(kind string, spec MultitrackSpec) string {
+	if mt.isMultipleNamespaces {
+		return resourceID(kind, spec)
+	}
+	return fmt.Sprintf("%s/%s", kind, spec.ResourceName)
+}
+
+// tableResourceName returns resource name for the status progress tables: name or namespace/name
+func (mt *multitracker) tableResourceName(spec MultitrackSpec) string {
+	if mt.isMultipleNamespaces {
+		return resourceKey(spec)
+	}
+	return spec.ResourceName
+}
+
+func hasMultipleNamespaces(specs MultitrackSpecs) bool {
+	namespaces := []string{}
+	for _, s := range allSpecs(specs) {
+		namespaces = appendElemIfNotExist(namespaces, s.Spec.Namespace)
+	}
+	for _, spec := range specs.Selectors {
+		namespaces = appendElemIfNotExist(namespaces, spec.Namespace)
+	}
+	return len(namespaces) > 1
+}
+
+type multitrackerKind struct {
+	Kind     string
+	Specs    map[string]MultitrackSpec
+	States   map[string]*multitrackerResourceState
+	Contexts map[string]*multitrackerContext
+}
+
+// ResourceKind returns kind of the resource for user messages,
+// kind of the generic resource is defined by its spec
+func (k multitrackerKind) ResourceKind(spec MultitrackSpec) string {
+	if k.Kind == "generic" {
+		return genericResourceKind(spec)
+	}
+	return k.Kind
+}
+
+func (mt *multitracker) trackedKinds() []multitrackerKind {
+	return []multitrackerKind{
+		{"deploy", mt.DeploymentsSpecs, mt.TrackingDeployments, mt.DeploymentsContexts},
+		{"sts", mt.StatefulSetsSpecs, mt.TrackingStatefulSets, mt.StatefulSetsContexts},
+		{"ds", mt.DaemonSetsSpecs, mt.TrackingDaemonSets, mt.DaemonSetsContexts},
+		{"job", mt.JobsSpecs, mt.TrackingJobs, mt.JobsContexts},
+		{"cronjob", mt.CronJobsSpecs, mt.TrackingCronJobs, mt.CronJobsContexts},
+		{"po", mt.PodsSpecs, mt.TrackingPods, mt.PodsContexts},
+		{"svc", mt.ServicesSpecs, mt.TrackingServices, mt.ServicesContexts},
+		{"ing", mt.IngressesSpecs, mt.TrackingIngresses, mt.IngressesContexts},
+		{"pvc", mt.PVCsSpecs, mt.TrackingPVCs, mt.PVCsContexts},
+		{"rollout", mt.RolloutsSpecs, mt.TrackingRollouts, mt.RolloutsContexts},
+		{"generic", mt.GenericSpecs, mt.TrackingGeneric, mt.GenericContexts},
+	}
+}
+
 type multitrackerContext struct {
 	Context    context.Context
 	CancelFunc context.CancelFunc
@@ -432,6 +991,8 @@ const (
 	resourceFailed            multitrackerResourceStatus = "resourceFailed"
 	resourceHoping            multitrackerResourceStatus = "resourceHoping"
 	resourceActiveAfterHoping multitrackerResourceStatus = "resourceActiveAfterHoping"
+	// resourceTimedOut is the status of the timed out resource with IgnoreAndContinueDeployProcess fail mode
+	resourceTimedOut multitrackerResourceStatus = "resourceTimedOut"
 )
 
 type multitrackerResourceState struct {
@@ -439,20 +1000,39 @@ type multitrackerResourceState struct {
 	FailedReason             string
 	FailuresCount            int
 	FailuresCountAfterHoping int
+
+	// TrackingStartedAt is used to check TimeoutSeconds of the resource
+	TrackingStartedAt time.Time
+	// AddedAt and ReadyAt are the times the resource has been found and has become ready,
+	// zero values mean that the resource has not been found or is not ready yet.
+	AddedAt time.Time
+	ReadyAt time.Time
+
+	// FailureStartedAt is the time of the first error of the current failure,
+	// zero value means that the resource is not failing now.
+	FailureStartedAt time.Time
+	// PendingFailureReason is the reason of the last error, which occurred
+	// within FailureThresholdSeconds and has not been counted yet.
+	PendingFailureReason string
+
+	// IsReadyReached is set when the resource has become ready and is waiting
+	// for the ReadyStabilitySeconds period to pass.
+	IsReadyReached bool
+	// ReadySince is the start of the current readiness stability period,
+	// zero value means that the resource is not ready now.
+	ReadySince time.Time
+	// ReadyRestartsCount is the pods restarts count at the start of the readiness stability period,
+	// -1 means that the count is not known yet.
+	ReadyRestartsCount int32
 }
 
 func newMultitrackerResourceState(spec MultitrackSpec) *multitrackerResourceState {
-	return &multitrackerResourceState{Status: resourceActive}
+	return &multitrackerResourceState{Status: resourceActive, TrackingStartedAt: time.Now()}
 }
 
 func (mt *multitracker) hasFailedTrackingResources() bool {
-	for _, states := range []map[string]*multitrackerResourceState{
-		mt.TrackingDeployments,
-		mt.TrackingStatefulSets,
-		mt.TrackingDaemonSets,
-		mt.TrackingJobs,
-	} {
-		for _, state := range states {
+	for _, k := range mt.trackedKinds() {
+		for _, state := range k.States {
 			if state.Status == resourceFailed {
 				return true
 			}
@@ -464,126 +1044,409 @@ func (mt *multitracker) hasFailedTrackingResources() bool {
 func (mt *multitracker) formatFailedTrackingResourcesError() error {
 	msgParts := []string{}
 
-	for name, state := range mt.TrackingDeployments {
-		if state.Status != resourceFailed {
-			continue
+	for _, k := range mt.trackedKinds() {
+		for name, state := range k.States {
+			if state.Status != resourceFailed {
+				continue
+			}
+			spec := k.Specs[name]
+			msgParts = append(msgParts, fmt.Sprintf("%s failed: %s", mt.fullResourceName(k.ResourceKind(spec), spec), state.FailedReason))
 		}
-		msgParts = append(msgParts, fmt.Sprintf("deploy/%s failed: %s", name, state.FailedReason))
 	}
-	for name, state := range mt.TrackingStatefulSets {
-		if state.Status != resourceFailed {
-			continue
+
+	return fmt.Errorf("%s", strings.Join(msgParts, "\n"))
+}
+
+func (mt *multitracker) handleResourceReadyCondition(resourcesStates map[string]*multitrackerResourceState, spec MultitrackSpec) error {
+	state := resourcesStates[resourceKey(spec)]
+	if state.Status == resourceFailed || state.Status == resourceTimedOut {
+		// Resource is timed out, tracker is being stopped
+		return tracker.StopTrack
+	}
+
+	mt.resetResourceFailure(resourcesStates, spec)
+
+	if *spec.ReadyStabilitySeconds > 0 && spec.TrackTerminationMode != WaitUntilResourceDeleted && state.Status != resourceSucceeded {
+		// Continue tracking until the resource stays ready for ReadyStabilitySeconds,
+		// resource is marked as succeeded by checkReadyStability
+		if !state.IsReadyReached || state.ReadySince.IsZero() {
+			state.IsReadyReached = true
+			state.ReadySince = time.Now()
+			state.ReadyRestartsCount = -1
 		}
-		msgParts = append(msgParts, fmt.Sprintf("sts/%s failed: %s", name, state.FailedReason))
+		return nil
 	}
-	for name, state := range mt.TrackingDaemonSets {
-		if state.Status != resourceFailed {
-			continue
+
+	return mt.handleResourceSucceeded(resourcesStates, spec)
+}
+
+// handleResourceAdded should be called when the resource is found by its tracker
+func (mt *multitracker) handleResourceAdded(resourcesStates map[string]*multitrackerResourceState, spec MultitrackSpec) {
+	if state := resourcesStates[resourceKey(spec)]; state.AddedAt.IsZero() {
+		state.AddedAt = time.Now()
+	}
+}
+
+func (mt *multitracker) handleResourceSucceeded(resourcesStates map[string]*multitrackerResourceState, spec MultitrackSpec) error {
+	resourcesStates[resourceKey(spec)].Status = resourceSucceeded
+	resourcesStates[resourceKey(spec)].ReadyAt = time.Now()
+
+	if spec.ShowLogsUntil == EndOfDeploy {
+		// Continue tracking to show logs, tracker will be stopped
+		// when all other resources are ready
+		if err := mt.applyTrackTerminationMode(); err != nil {
+			return fmt.Errorf("unable to apply termination mode: %s", err)
 		}
-		msgParts = append(msgParts, fmt.Sprintf("ds/%s failed: %s", name, state.FailedReason))
+		return nil
+	}
+
+	return tracker.StopTrack
+}
+
+// resetResourceFailure should be called when the resource has no errors anymore,
+// so that the next error starts a new failure with a new FailureThresholdSeconds period.
+func (mt *multitracker) resetResourceFailure(resourcesStates map[string]*multitrackerResourceState, spec MultitrackSpec) {
+	state := resourcesStates[resourceKey(spec)]
+	state.FailureStartedAt = time.Time{}
+	state.PendingFailureReason = ""
+}
+
+// handleResourceReadyStability should be called on each status of the resource to check that the resource,
+// which has become ready, stays ready without pods restarts during the ReadyStabilitySeconds period.
+func (mt *multitracker) handleResourceReadyStability(resourcesStates map[string]*multitrackerResourceState, kind string, spec MultitrackSpec, isReady bool, restartsCount int32) error {
+	state := resourcesStates[resourceKey(spec)]
+	if !state.IsReadyReached || state.Status == resourceSucceeded || state.Status == resourceFailed || state.Status == resourceTimedOut {
+		return nil
 	}
-	for name, state := range mt.TrackingJobs {
-		if state.Status != resourceFailed {
-			continue
+
+	if !isReady {
+		if state.ReadySince.IsZero() {
+			return nil
 		}
-		msgParts = append(msgParts, fmt.Sprintf("job/%s failed: %s", name, state.FailedReason))
+		state.ReadySince = time.Time{}
+
+		reason := fmt.Sprintf("became not ready within %ds readiness stability period", *spec.ReadyStabilitySeconds)
+		mt.displayResourceErrorF(kind, spec, "%s", reason)
+		mt.emitResourceEvent(kind, spec, Event{Type: ResourceFailedEvent, Message: reason})
+
+		return mt.handleResourceFailure(resourcesStates, kind, spec, reason)
 	}
 
-	return fmt.Errorf("%s", strings.Join(msgParts, "\n"))
+	if state.ReadySince.IsZero() {
+		mt.displayResourceTrackerMessageF(kind, spec, "is READY, waiting %ds readiness stability period again", *spec.ReadyStabilitySeconds)
+		state.ReadySince = time.Now()
+		state.ReadyRestartsCount = restartsCount
+		return nil
+	}
+
+	if state.ReadyRestartsCount < 0 || restartsCount < state.ReadyRestartsCount {
+		state.ReadyRestartsCount = restartsCount
+		return nil
+	}
+
+	if restartsCount > state.ReadyRestartsCount {
+		reason := fmt.Sprintf("%d pods restarts within %ds readiness stability period", restartsCount-state.ReadyRestartsCount, *spec.ReadyStabilitySeconds)
+		state.ReadyRestartsCount = restartsCount
+
+		mt.displayResourceErrorF(kind, spec, "%s", reason)
+		mt.emitResourceEvent(kind, spec, Event{Type: ResourceFailedEvent, Message: reason})
+
+		return mt.handleResourceFailure(resourcesStates, kind, spec, reason)
+	}
+
+	return nil
 }
 
-func (mt *multitracker) handleResourceReadyCondition(resourcesStates map[string]*multitrackerResourceState, spec MultitrackSpec) error {
-	resourcesStates[spec.ResourceName].Status = resourceSucceeded
-	return tracker.StopTrack
+// checkReadyStability marks resources as succeeded, which have stayed ready for the ReadyStabilitySeconds period.
+func (mt *multitracker) checkReadyStability() error {
+	for _, k := range mt.trackedKinds() {
+		for name, state := range k.States {
+			if !state.IsReadyReached || state.ReadySince.IsZero() || state.Status == resourceFailed || state.Status == resourceSucceeded || state.Status == resourceTimedOut {
+				continue
+			}
+
+			spec := k.Specs[name]
+			if time.Since(state.ReadySince) < time.Duration(*spec.ReadyStabilitySeconds)*time.Second {
+				continue
+			}
+
+			state.IsReadyReached = false
+			mt.displayMultitrackServiceMessageF("%s has been ready for %ds\n", mt.fullResourceName(k.ResourceKind(spec), spec), *spec.ReadyStabilitySeconds)
+
+			if err := mt.handleResourceSucceeded(k.States, spec); err == tracker.StopTrack {
+				// Tracker returns without error when its context is canceled and then termination mode is applied
+				if ctx, hasKey := k.Contexts[name]; hasKey {
+					ctx.CancelFunc()
+				}
+			} else if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
 func (mt *multitracker) handleResourceFailure(resourcesStates map[string]*multitrackerResourceState, kind string, spec MultitrackSpec, reason string) error {
+	state := resourcesStates[resourceKey(spec)]
+
+	if state.Status == resourceSucceeded {
+		// Resource is already ready and tracked only to show logs until the end of deploy process
+		mt.displayMultitrackServiceMessageF("Error occurred for already ready %s: ignoring\n", mt.fullResourceName(kind, spec))
+		return nil
+	}
+
+	if state.Status == resourceFailed || state.Status == resourceTimedOut {
+		// Error has been already reported
+		return nil
+	}
+
+	// Any error interrupts the readiness stability period,
+	// the period is started again when the resource is ready
+	state.ReadySince = time.Time{}
+
+	if state.FailureStartedAt.IsZero() {
+		state.FailureStartedAt = time.Now()
+	}
+
+	if *spec.FailureThresholdSeconds > 0 {
+		failureThreshold := time.Duration(*spec.FailureThresholdSeconds) * time.Second
+		if time.Since(state.FailureStartedAt) < failureThreshold {
+			state.PendingFailureReason = reason
+			mt.displayMultitrackServiceMessageF("Error occurred for %s, waiting %ds failure threshold before counting errors\n", mt.fullResourceName(kind, spec), *spec.FailureThresholdSeconds)
+			return nil
+		}
+	}
+
+	if pendingDependencies := mt.getPendingDependencies(kind, spec); len(pendingDependencies) > 0 {
+		state.PendingFailureReason = reason
+		mt.displayMultitrackServiceMessageF("Error occurred for %s, waiting until following dependencies are ready before counting errors: %s\n", mt.fullResourceName(kind, spec), strings.Join(pendingDependencies, ", "))
+		return nil
+	}
+
+	state.PendingFailureReason = ""
+
+	return mt.countResourceFailure(resourcesStates, kind, spec, reason)
+}
+
+func (mt *multitracker) countResourceFailure(resourcesStates map[string]*multitrackerResourceState, kind string, spec MultitrackSpec, reason string) error {
 	switch spec.FailMode {
 	case FailWholeDeployProcessImmediately:
-		resourcesStates[spec.ResourceName].FailuresCount++
+		resourcesStates[resourceKey(spec)].FailuresCount++
 
-		if resourcesStates[spec.ResourceName].FailuresCount <= *spec.AllowFailuresCount {
-			mt.displayMultitrackServiceMessageF("%d/%d allowed errors occurred for %s/%s: continue tracking\n", resourcesStates[spec.ResourceName].FailuresCount, *spec.AllowFailuresCount, kind, spec.ResourceName)
+		if resourcesStates[resourceKey(spec)].FailuresCount <= *spec.AllowFailuresCount {
+			mt.displayMultitrackServiceMessageF("%d/%d allowed errors occurred for %s: continue tracking\n", resourcesStates[resourceKey(spec)].FailuresCount, *spec.AllowFailuresCount, mt.fullResourceName(kind, spec))
 			return nil
 		}
 
-		mt.displayMultitrackServiceMessageF("Allowed failures count for %s/%s exceeded %d errors: stop tracking immediately!\n", kind, spec.ResourceName, *spec.AllowFailuresCount)
+		mt.displayMultitrackServiceMessageF("Allowed failures count for %s exceeded %d errors: stop tracking immediately!\n", mt.fullResourceName(kind, spec), *spec.AllowFailuresCount)
 
-		resourcesStates[spec.ResourceName].Status = resourceFailed
-		resourcesStates[spec.ResourceName].FailedReason = reason
+		resourcesStates[resourceKey(spec)].Status = resourceFailed
+		resourcesStates[resourceKey(spec)].FailedReason = reason
 
 		return ErrFailWholeDeployProcessImmediately
 
 	case HopeUntilEndOfDeployProcess:
 
 	handleResourceState:
-		switch resourcesStates[spec.ResourceName].Status {
+		switch resourcesStates[resourceKey(spec)].Status {
 		case resourceActive:
-			resourcesStates[spec.ResourceName].Status = resourceHoping
+			resourcesStates[resourceKey(spec)].Status = resourceHoping
 			goto handleResourceState
 
 		case resourceHoping:
 			activeResourcesNames := mt.getActiveResourcesNames()
 			if len(activeResourcesNames) > 0 {
-				mt.displayMultitrackServiceMessageF("Error occurred for %s/%s, waiting until following resources are ready before counting errors (HopeUntilEndOfDeployProcess fail mode is active): %s\n", kind, spec.ResourceName, strings.Join(activeResourcesNames, ", "))
+				mt.displayMultitrackServiceMessageF("Error occurred for %s, waiting until following resources are ready before counting errors (HopeUntilEndOfDeployProcess fail mode is active): %s\n", mt.fullResourceName(kind, spec), strings.Join(activeResourcesNames, ", "))
 				return nil
 			}
 
-			resourcesStates[spec.ResourceName].Status = resourceActiveAfterHoping
+			resourcesStates[resourceKey(spec)].Status = resourceActiveAfterHoping
 			goto handleResourceState
 
 		case resourceActiveAfterHoping:
-			resourcesStates[spec.ResourceName].FailuresCount++
+			resourcesStates[resourceKey(spec)].FailuresCount++
 
-			if resourcesStates[spec.ResourceName].FailuresCount <= *spec.AllowFailuresCount {
-				mt.displayMultitrackServiceMessageF("%d/%d allowed errors occurred for %s/%s: continue tracking\n", resourcesStates[spec.ResourceName].FailuresCount, *spec.AllowFailuresCount, kind, spec.ResourceName)
+			if resourcesStates[resourceKey(spec)].FailuresCount <= *spec.AllowFailuresCount {
+				mt.displayMultitrackServiceMessageF("%d/%d allowed errors occurred for %s: continue tracking\n", resourcesStates[resourceKey(spec)].FailuresCount, *spec.AllowFailuresCount, mt.fullResourceName(kind, spec))
 				return nil
 			}
 
-			mt.displayMultitrackServiceMessageF("Allowed failures count for %s/%s exceeded %d errors: stop tracking immediately!\n", kind, spec.ResourceName, *spec.AllowFailuresCount)
+			mt.displayMultitrackServiceMessageF("Allowed failures count for %s exceeded %d errors: stop tracking immediately!\n", mt.fullResourceName(kind, spec), *spec.AllowFailuresCount)
 
-			resourcesStates[spec.ResourceName].Status = resourceFailed
-			resourcesStates[spec.ResourceName].FailedReason = reason
+			resourcesStates[resourceKey(spec)].Status = resourceFailed
+			resourcesStates[resourceKey(spec)].FailedReason = reason
 
 			return ErrFailWholeDeployProcessImmediately
 
 		default:
-			panic(fmt.Sprintf("%s/%s tracker is in unexpected state %#v", kind, spec.ResourceName, resourcesStates[spec.ResourceName].Status))
+			panic(fmt.Sprintf("%s tracker is in unexpected state %#v", mt.fullResourceName(kind, spec), resourcesStates[resourceKey(spec)].Status))
 		}
 
 	case IgnoreAndContinueDeployProcess:
-		resourcesStates[spec.ResourceName].FailuresCount++
-		mt.displayMultitrackServiceMessageF("%d errors occurred for %s/%s\n", resourcesStates[spec.ResourceName].FailuresCount, kind, spec.ResourceName)
+		resourcesStates[resourceKey(spec)].FailuresCount++
+		mt.displayMultitrackServiceMessageF("%d errors occurred for %s\n", resourcesStates[resourceKey(spec)].FailuresCount, mt.fullResourceName(kind, spec))
 		return nil
 
 	default:
-		panic(fmt.Sprintf("bad fail mode %#v for resource %s/%s", spec.FailMode, kind, spec.ResourceName))
+		panic(fmt.Sprintf("bad fail mode %#v for resource %s", spec.FailMode, mt.fullResourceName(kind, spec)))
 	}
 
 	return nil
 }
 
-func (mt *multitracker) getActiveResourcesNames() []string {
-	activeResources := []string{}
+// checkPendingFailures counts errors of resources, which have been held back
+// by FailureThresholdSeconds or by not ready dependencies and not followed by any new errors.
+func (mt *multitracker) checkPendingFailures() error {
+	for _, k := range mt.trackedKinds() {
+		for name, state := range k.States {
+			if state.PendingFailureReason == "" || state.Status == resourceFailed || state.Status == resourceSucceeded || state.Status == resourceTimedOut {
+				continue
+			}
 
-	for name, state := range mt.TrackingDeployments {
-		if state.Status == resourceActive {
-			activeResources = append(activeResources, fmt.Sprintf("deploy/%s", name))
+			spec := k.Specs[name]
+			failureThreshold := time.Duration(*spec.FailureThresholdSeconds) * time.Second
+			if time.Since(state.FailureStartedAt) < failureThreshold {
+				continue
+			}
+			if len(mt.getPendingDependencies(k.ResourceKind(spec), spec)) > 0 {
+				continue
+			}
+
+			reason := state.PendingFailureReason
+			state.PendingFailureReason = ""
+
+			if *spec.FailureThresholdSeconds > 0 {
+				mt.displayMultitrackServiceMessageF("%s has been failing for more than %ds\n", mt.fullResourceName(k.ResourceKind(spec), spec), *spec.FailureThresholdSeconds)
+			}
+
+			err := mt.countResourceFailure(k.States, k.ResourceKind(spec), spec, reason)
+			if err == ErrFailWholeDeployProcessImmediately {
+				if ctx, hasKey := k.Contexts[name]; hasKey {
+					ctx.CancelFunc()
+				}
+
+				mt.isFailed = true
+				mt.displayFailedTrackingResourcesServiceMessages()
+				return mt.formatFailedTrackingResourcesError()
+			} else if err != nil {
+				return err
+			}
 		}
 	}
-	for name, state := range mt.TrackingStatefulSets {
-		if state.Status == resourceActive {
-			activeResources = append(activeResources, fmt.Sprintf("sts/%s", name))
+
+	return nil
+}
+
+// checkTimeouts fails resources, which are not done within TimeoutSeconds.
+func (mt *multitracker) checkTimeouts() error {
+	for _, k := range mt.trackedKinds() {
+		for name, state := range k.States {
+			spec := k.Specs[name]
+			if *spec.TimeoutSeconds <= 0 || state.Status == resourceFailed || state.Status == resourceSucceeded || state.Status == resourceTimedOut {
+				continue
+			}
+			if time.Since(state.TrackingStartedAt) < time.Duration(*spec.TimeoutSeconds)*time.Second {
+				continue
+			}
+
+			reason := fmt.Sprintf("timed out after %ds", *spec.TimeoutSeconds)
+			if waitingForMessages := mt.resourceWaitingForMessages(k, spec); len(waitingForMessages) > 0 {
+				reason = fmt.Sprintf("timed out waiting for: %s", strings.Join(waitingForMessages, ", "))
+			}
+
+			mt.displayResourceErrorF(k.ResourceKind(spec), spec, "%s", reason)
+			mt.emitResourceEvent(k.ResourceKind(spec), spec, Event{Type: ResourceFailedEvent, Message: reason})
+
+			state.IsReadyReached = false
+			state.PendingFailureReason = ""
+
+			if ctx, hasKey := k.Contexts[name]; hasKey {
+				ctx.CancelFunc()
+			}
+
+			switch spec.FailMode {
+			case FailWholeDeployProcessImmediately:
+				state.Status = resourceFailed
+				state.FailedReason = reason
+
+				mt.isFailed = true
+				mt.displayFailedTrackingResourcesServiceMessages()
+				return mt.formatFailedTrackingResourcesError()
+
+			case HopeUntilEndOfDeployProcess:
+				// Deploy process fails when all other resources are done
+				mt.displayMultitrackServiceMessageF("%s timed out, continue tracking of other resources (HopeUntilEndOfDeployProcess fail mode is active)\n", mt.fullResourceName(k.ResourceKind(spec), spec))
+				state.Status = resourceFailed
+				state.FailedReason = reason
+
+			case IgnoreAndContinueDeployProcess:
+				state.FailuresCount++
+				mt.displayMultitrackServiceMessageF("%d errors occurred for %s\n", state.FailuresCount, mt.fullResourceName(k.ResourceKind(spec), spec))
+				state.Status = resourceTimedOut
+				state.FailedReason = reason
+
+			default:
+				panic(fmt.Sprintf("bad fail mode %#v for resource %s", spec.FailMode, mt.fullResourceName(k.ResourceKind(spec), spec)))
+			}
 		}
 	}
-	for name, state := range mt.TrackingDaemonSets {
-		if state.Status == resourceActive {
-			activeResources = append(activeResources, fmt.Sprintf("ds/%s", name))
+
+	return nil
+}
+
+// resourceWaitingForMessages returns conditions the resource is waiting for accordingly to its last status
+func (mt *multitracker) resourceWaitingForMessages(k multitrackerKind, spec MultitrackSpec) []string {
+	if spec.TrackTerminationMode == WaitUntilResourceDeleted {
+		return mt.DeletionsStatuses[resourceID(k.ResourceKind(spec), spec)].WaitingForMessages
+	}
+
+	key := resourceKey(spec)
+
+	switch k.Kind {
+	case "deploy":
+		return mt.DeploymentsStatuses[key].WaitingForMessages
+	case "sts":
+		return mt.StatefulSetsStatuses[key].WaitingForMessages
+	case "ds":
+		return mt.DaemonSetsStatuses[key].WaitingForMessages
+	case "job":
+		return mt.JobsStatuses[key].WaitingForMessages
+	case "cronjob":
+		return mt.CronJobsStatuses[key].WaitingForMessages
+	case "po":
+		status, hasKey := mt.PodsStatuses[key]
+		if !hasKey {
+			return nil
 		}
+		if status.RestartPolicy == corev1.RestartPolicyNever || status.RestartPolicy == corev1.RestartPolicyOnFailure {
+			return []string{fmt.Sprintf("phase %s->%s", status.Phase, corev1.PodSucceeded)}
+		}
+		return []string{fmt.Sprintf("ready containers %d->%d", status.ReadyContainers, status.TotalContainers)}
+	case "svc":
+		return mt.ServicesStatuses[key].WaitingForMessages
+	case "ing":
+		return mt.IngressesStatuses[key].WaitingForMessages
+	case "pvc":
+		return mt.PVCsStatuses[key].WaitingForMessages
+	case "rollout":
+		return mt.RolloutsStatuses[key].WaitingForMessages
+	case "generic":
+		return mt.GenericStatuses[key].WaitingForMessages
 	}
-	for name, state := range mt.TrackingJobs {
-		if state.Status == resourceActive {
-			activeResources = append(activeResources, fmt.Sprintf("job/%s", name))
+
+	return nil
+}
+
+func (mt *multitracker) getActiveResourcesNames() []string {
+	activeResources := []string{}
+
+	for _, k := range mt.trackedKinds() {
+		for name, state := range k.States {
+			if state.Status == resourceActive {
+				spec := k.Specs[name]
+				activeResources = append(activeResources, mt.fullResourceName(k.ResourceKind(spec), spec))
+			}
 		}
 	}
 
@@ -5,11 +5,16 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
+	corev1 "k8s.io/api/core/v1"
 
+	"github.com/flant/kubedog/pkg/display"
+	"github.com/flant/kubedog/pkg/tracker/deployment"
 	"github.com/flant/kubedog/pkg/tracker/indicators"
 	"github.com/flant/kubedog/pkg/tracker/pod"
+	"github.com/flant/kubedog/pkg/tracker/pvc"
 	"github.com/flant/kubedog/pkg/utils"
 	"github.com/flant/logboek"
 )
@@ -17,13 +22,29 @@ import (
 var (
 	statusProgressTableRatio    = []float64{.58, .11, .12, .19}
 	statusProgressSubTableRatio = []float64{.40, .15, .20, .25}
+
+	genericStatusProgressTableRatio = []float64{.50, .11, .39}
+	cronJobStatusProgressTableRatio = []float64{.46, .20, .16, .18}
+	serviceStatusProgressTableRatio = []float64{.40, .15, .13, .32}
+	ingressStatusProgressTableRatio = []float64{.30, .30, .25, .15}
+	pvcStatusProgressTableRatio     = []float64{.40, .20, .15, .25}
+	pvcStatusProgressSubTableRatio  = []float64{.40, .20, .15, .25}
+	rolloutStatusProgressTableRatio = []float64{.40, .11, .12, .19, .18}
+	summaryTableRatio               = []float64{.40, .16, .14, .12, .18}
+
+	deletionStatusProgressTableRatio    = []float64{.40, .15, .30, .15}
+	deletionStatusProgressSubTableRatio = []float64{.40, .20, .40}
 )
 
-func (mt *multitracker) displayResourceLogChunk(resourceKind string, spec MultitrackSpec, header string, chunk *pod.ContainerLogChunk) {
+func (mt *multitracker) displayResourceLogChunk(resourceKind string, spec MultitrackSpec, podName, header string, chunk *pod.ContainerLogChunk) {
 	if spec.SkipLogs {
 		return
 	}
 
+	if spec.SkipLogsUntilDependenciesReady && len(mt.getPendingDependencies(resourceKind, spec)) > 0 {
+		return
+	}
+
 	for _, containerName := range spec.SkipLogsForContainers {
 		if containerName == chunk.ContainerName {
 			return
@@ -48,30 +69,71 @@ func (mt *multitracker) displayResourceLogChunk(resourceKind string, spec Multit
 		logRegexp = spec.LogRegex
 	}
 
-	showLines := []string{}
+	showLines := []display.LogLine{}
 
 	if logRegexp != nil {
 		for _, logLine := range chunk.LogLines {
 			message := logRegexp.FindString(logLine.Message)
 			if message != "" {
-				showLines = append(showLines, logLine.Message)
+				showLines = append(showLines, logLine)
 			}
 		}
 	} else {
-		for _, logLine := range chunk.LogLines {
-			showLines = append(showLines, logLine.Message)
-		}
+		showLines = append(showLines, chunk.LogLines...)
 	}
 
-	if len(showLines) > 0 {
-		mt.setLogProcess(fmt.Sprintf("%s/%s %s logs", resourceKind, spec.ResourceName, header), logboek.LevelLogProcessStartOptions{})
+	// Termination of the previous instance is shown even if there are no logs
+	if len(showLines) > 0 || chunk.PreviousInstance != nil {
+		mt.setLogProcess(fmt.Sprintf("%s %s logs", mt.fullResourceName(resourceKind, spec), header), logboek.LevelLogProcessStartOptions{})
 
 		for _, line := range showLines {
-			logboek.OutF("%s\n", line)
+			logboek.OutF("%s\n", line.Message)
+
+			mt.emitResourceEvent(resourceKind, spec, Event{
+				Type:             LogLineEvent,
+				Pod:              podName,
+				Container:        chunk.ContainerName,
+				Message:          line.Message,
+				LogTimestamp:     line.Timestamp,
+				PreviousInstance: eventContainerTermination(chunk.PreviousInstance),
+			})
+		}
+
+		if chunk.PreviousInstance != nil {
+			if chunk.PreviousInstance.Message != "" {
+				logboek.OutF("Termination message: %s\n", chunk.PreviousInstance.Message)
+			}
+
+			mt.emitResourceEvent(resourceKind, spec, Event{
+				Type:             ContainerTerminatedEvent,
+				Pod:              podName,
+				Container:        chunk.ContainerName,
+				Message:          chunk.PreviousInstance.Message,
+				PreviousInstance: eventContainerTermination(chunk.PreviousInstance),
+			})
 		}
 	}
 }
 
+// isPodLogsVisible checks ShowLogsUntil condition for the logs of the pod, which belongs to the tracked resource
+func isPodLogsVisible(spec MultitrackSpec, pods map[string]pod.PodStatus, podName string) bool {
+	switch spec.ShowLogsUntil {
+	case PodIsReady:
+		if podStatus, hasKey := pods[podName]; hasKey && podStatus.IsReady {
+			return false
+		}
+		return true
+
+	case ControllerIsReady, EndOfDeploy:
+		// Controller tracker is stopped when controller is ready,
+		// unless EndOfDeploy is specified
+		return true
+
+	default:
+		panic(fmt.Sprintf("unknown ShowLogsUntil condition %#v", spec.ShowLogsUntil))
+	}
+}
+
 func (mt *multitracker) setLogProcess(header string, options logboek.LevelLogProcessStartOptions) {
 	if mt.currentLogProcessHeader != header {
 		mt.resetLogProcess()
@@ -98,13 +160,13 @@ func (mt *multitracker) resetLogProcess() {
 }
 
 func (mt *multitracker) displayResourceTrackerMessageF(resourceKind string, spec MultitrackSpec, format string, a ...interface{}) {
-	resource := fmt.Sprintf("%s/%s", resourceKind, spec.ResourceName)
+	resource := resourceID(resourceKind, spec)
 	msg := fmt.Sprintf(format, a...)
 	mt.serviceMessagesByResource[resource] = append(mt.serviceMessagesByResource[resource], msg)
 
 	if spec.ShowServiceMessages {
 		mt.setLogProcess(
-			fmt.Sprintf("%s/%s service messages", resourceKind, spec.ResourceName),
+			fmt.Sprintf("%s service messages", mt.fullResourceName(resourceKind, spec)),
 			logboek.LevelLogProcessStartOptions{
 				Style: logboek.DetailsStyle(),
 			},
@@ -115,13 +177,15 @@ func (mt *multitracker) displayResourceTrackerMessageF(resourceKind string, spec
 }
 
 func (mt *multitracker) displayResourceEventF(resourceKind string, spec MultitrackSpec, format string, a ...interface{}) {
-	resource := fmt.Sprintf("%s/%s", resourceKind, spec.ResourceName)
+	resource := resourceID(resourceKind, spec)
 	msg := fmt.Sprintf(fmt.Sprintf("event: %s", format), a...)
 	mt.serviceMessagesByResource[resource] = append(mt.serviceMessagesByResource[resource], msg)
 
+	mt.emitResourceEvent(resourceKind, spec, Event{Type: EventMessageEvent, Message: fmt.Sprintf(format, a...)})
+
 	if spec.ShowServiceMessages {
 		mt.setLogProcess(
-			fmt.Sprintf("%s/%s service messages", resourceKind, spec.ResourceName),
+			fmt.Sprintf("%s service messages", mt.fullResourceName(resourceKind, spec)),
 			logboek.LevelLogProcessStartOptions{Style: logboek.DetailsStyle()},
 		)
 
@@ -131,46 +195,24 @@ func (mt *multitracker) displayResourceEventF(resourceKind string, spec Multitra
 
 func (mt *multitracker) displayResourceErrorF(resourceKind string, spec MultitrackSpec, format string, a ...interface{}) {
 	mt.resetLogProcess()
-	logboek.LogWarnF(fmt.Sprintf("%s/%s ERROR: %s\n", resourceKind, spec.ResourceName, format), a...)
+	logboek.LogWarnF(fmt.Sprintf("%s ERROR: %s\n", mt.fullResourceName(resourceKind, spec), format), a...)
 }
 
 func (mt *multitracker) displayFailedTrackingResourcesServiceMessages() {
-	for name, state := range mt.TrackingDeployments {
-		if state.Status != resourceFailed {
-			continue
-		}
-
-		spec := mt.DeploymentsSpecs[name]
-		mt.displayResourceServiceMessages("deploy", spec)
-	}
-	for name, state := range mt.TrackingStatefulSets {
-		if state.Status != resourceFailed {
-			continue
-		}
-
-		spec := mt.StatefulSetsSpecs[name]
-		mt.displayResourceServiceMessages("sts", spec)
-	}
-	for name, state := range mt.TrackingDaemonSets {
-		if state.Status != resourceFailed {
-			continue
-		}
+	for _, k := range mt.trackedKinds() {
+		for name, state := range k.States {
+			if state.Status != resourceFailed {
+				continue
+			}
 
-		spec := mt.DaemonSetsSpecs[name]
-		mt.displayResourceServiceMessages("ds", spec)
-	}
-	for name, state := range mt.TrackingJobs {
-		if state.Status != resourceFailed {
-			continue
+			spec := k.Specs[name]
+			mt.displayResourceServiceMessages(k.ResourceKind(spec), spec)
 		}
-
-		spec := mt.JobsSpecs[name]
-		mt.displayResourceServiceMessages("job", spec)
 	}
 }
 
 func (mt *multitracker) displayResourceServiceMessages(resourceKind string, spec MultitrackSpec) {
-	lines := mt.serviceMessagesByResource[fmt.Sprintf("%s/%s", resourceKind, spec.ResourceName)]
+	lines := mt.serviceMessagesByResource[resourceID(resourceKind, spec)]
 
 	if len(lines) > 0 {
 		mt.resetLogProcess()
@@ -178,7 +220,7 @@ func (mt *multitracker) displayResourceServiceMessages(resourceKind string, spec
 		logboek.LogOptionalLn()
 
 		_ = logboek.Default.LogBlock(
-			fmt.Sprintf("Failed resource %s/%s service messages", resourceKind, spec.ResourceName),
+			fmt.Sprintf("Failed resource %s service messages", mt.fullResourceName(resourceKind, spec)),
 			logboek.LevelLogBlockOptions{
 				WithoutLogOptionalLn: true,
 				Style:                logboek.DetailsStyle(),
@@ -225,26 +267,181 @@ func (mt *multitracker) displayStatusProgress() error {
 		mt.displayDaemonSetsStatusProgress()
 		mt.displayStatefulSetsStatusProgress()
 		mt.displayJobsProgress()
+		mt.displayCronJobsStatusProgress()
+		mt.displayPodsStatusProgress()
+		mt.displayServicesStatusProgress()
+		mt.displayIngressesStatusProgress()
+		mt.displayPVCsStatusProgress()
+		mt.displayRolloutsStatusProgress()
+		mt.displayGenericStatusProgress()
+		mt.displayDeletionsStatusProgress()
 
 		return nil
 	})
 
 	logboek.LogOptionalLn()
 
+	mt.emitStatusEvents()
+
 	return nil
 }
 
-func (mt *multitracker) displayJobsProgress() {
-	t := utils.NewTable(statusProgressTableRatio...)
+// displaySummary shows the outcome of tracking of each resource when tracking is done
+func (mt *multitracker) displaySummary() {
+	t := utils.NewTable(summaryTableRatio...)
 	t.SetWidth(logboek.ContentWidth() - 1)
-	t.Header("JOB", "ACTIVE", "DURATION", "SUCCEEDED/FAILED")
+	t.Header("RESOURCE", "STATE", "READY IN", "FAILURES", "RESTARTS")
+
+	rows := 0
+	for _, k := range mt.trackedKinds() {
+		for _, name := range sortedResourcesNames(k.States) {
+			spec := k.Specs[name]
+			res := mt.resourceResult(k, spec)
+
+			disableWarningColors := spec.FailMode == IgnoreAndContinueDeployProcess
+			isReady := res.State == "Succeeded"
+			isFailed := res.State == "Failed" || res.State == "TimedOut"
+
+			resource := formatResourceCaption(mt.fullResourceName(res.Kind, spec), spec.FailMode, isReady, isFailed, true)
+
+			readyIn := "-"
+			if isReady {
+				readyIn = res.ReadyDuration.Truncate(time.Second).String()
+			}
+
+			args := []interface{}{resource, res.State, readyIn, res.FailuresCount, res.Restarts}
+			if res.FailedReason != "" {
+				args = append(args, formatResourceError(disableWarningColors, res.FailedReason))
+			}
+			t.Row(args...)
+			rows++
+		}
+	}
+
+	if rows == 0 {
+		return
+	}
+
+	mt.resetLogProcess()
+	logboek.LogOptionalLn()
+
+	_ = logboek.Default.LogBlock(color.New(color.Bold).Sprint("Summary"), logboek.LevelLogBlockOptions{WithoutLogOptionalLn: true}, func() error {
+		_, _ = logboek.OutF(t.Render())
+		return nil
+	})
+
+	logboek.LogOptionalLn()
+}
+
+func (mt *multitracker) displayDeletionsStatusProgress() {
+	t := utils.NewTable(deletionStatusProgressTableRatio...)
+	t.SetWidth(logboek.ContentWidth() - 1)
+	t.Header("DELETING", "STATUS", "FINALIZERS", "PODS")
+
+	var ids []string
+	specsByID := make(map[string]MultitrackSpec)
+	kindsByID := make(map[string]string)
+	for _, k := range mt.trackedKinds() {
+		for _, spec := range k.Specs {
+			if spec.TrackTerminationMode != WaitUntilResourceDeleted {
+				continue
+			}
+			id := resourceID(k.ResourceKind(spec), spec)
+			ids = append(ids, id)
+			specsByID[id] = spec
+			kindsByID[id] = k.ResourceKind(spec)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		status := mt.DeletionsStatuses[id]
+		spec := specsByID[id]
+
+		resource := formatResourceCaption(mt.fullResourceName(kindsByID[id], spec), spec.FailMode, status.IsDeleted, false, true)
+
+		state := "-"
+		switch {
+		case status.IsDeleted:
+			state = "Deleted"
+		case status.IsTerminating:
+			state = "Terminating"
+		case status.IsExists:
+			state = "Exists"
+		}
+
+		finalizers := "-"
+		if len(status.Finalizers) > 0 {
+			finalizers = strings.Join(status.Finalizers, ", ")
+		}
+
+		args := []interface{}{resource, state, finalizers, fmt.Sprintf("%d", len(status.Pods))}
+		if !status.IsDeleted && len(status.WaitingForMessages) > 0 {
+			args = append(args, color.New(color.FgBlue).Sprintf("Waiting for: %s", strings.Join(status.WaitingForMessages, ", ")))
+		}
+		t.Row(args...)
 
+		if len(status.Pods) > 0 {
+			st := t.SubTable(deletionStatusProgressSubTableRatio...)
+			st.Header("POD", "STATUS", "FINALIZERS")
+
+			podsNames := []string{}
+			for podName := range status.Pods {
+				podsNames = append(podsNames, podName)
+			}
+			sort.Strings(podsNames)
+
+			var podRows [][]interface{}
+			for _, podName := range podsNames {
+				podStatus := status.Pods[podName]
+
+				podState := podStatus.Phase
+				if podStatus.IsTerminating {
+					podState = "Terminating"
+				}
+
+				podFinalizers := "-"
+				if len(podStatus.Finalizers) > 0 {
+					podFinalizers = strings.Join(podStatus.Finalizers, ", ")
+				}
+
+				podRows = append(podRows, []interface{}{podName, podState, podFinalizers})
+			}
+
+			st.Rows(podRows...)
+			st.Commit()
+		}
+
+		mt.PrevDeletionsStatuses[id] = status
+	}
+
+	if len(ids) > 0 {
+		_, _ = logboek.OutF(t.Render())
+	}
+}
+
+// tableResourcesNames returns sorted names of the resources shown in the table of the kind,
+// resources which are waited for deletion are shown in the separate table
+func tableResourcesNames(specs map[string]MultitrackSpec) []string {
 	resourcesNames := []string{}
-	for name := range mt.JobsSpecs {
+	for name, spec := range specs {
+		if spec.TrackTerminationMode == WaitUntilResourceDeleted {
+			continue
+		}
 		resourcesNames = append(resourcesNames, name)
 	}
 	sort.Strings(resourcesNames)
 
+	return resourcesNames
+}
+
+func (mt *multitracker) displayJobsProgress() {
+	t := utils.NewTable(statusProgressTableRatio...)
+	t.SetWidth(logboek.ContentWidth() - 1)
+	t.Header("JOB", "ACTIVE", "DURATION", "SUCCEEDED/FAILED")
+
+	resourcesNames := tableResourcesNames(mt.JobsSpecs)
+
 	for _, name := range resourcesNames {
 		prevStatus := mt.PrevJobsStatuses[name]
 		status := mt.JobsStatuses[name]
@@ -254,7 +451,7 @@ func (mt *multitracker) displayJobsProgress() {
 		showProgress := status.StatusGeneration > prevStatus.StatusGeneration
 		disableWarningColors := spec.FailMode == IgnoreAndContinueDeployProcess
 
-		resource := formatResourceCaption(name, spec.FailMode, status.IsSucceeded, status.IsFailed, true)
+		resource := formatResourceCaption(mt.tableResourceName(spec), spec.FailMode, status.IsSucceeded, status.IsFailed, true)
 
 		succeeded := "-"
 		if status.SucceededIndicator != nil {
@@ -294,16 +491,503 @@ func (mt *multitracker) displayJobsProgress() {
 	}
 }
 
+func (mt *multitracker) displayCronJobsStatusProgress() {
+	t := utils.NewTable(cronJobStatusProgressTableRatio...)
+	t.SetWidth(logboek.ContentWidth() - 1)
+	t.Header("CRONJOB", "SCHEDULE", "LAST SCHEDULE", "ACTIVE")
+
+	resourcesNames := tableResourcesNames(mt.CronJobsSpecs)
+
+	for _, name := range resourcesNames {
+		prevStatus := mt.PrevCronJobsStatuses[name]
+		status := mt.CronJobsStatuses[name]
+		spec := mt.CronJobsSpecs[name]
+
+		showProgress := status.StatusGeneration > prevStatus.StatusGeneration
+		disableWarningColors := spec.FailMode == IgnoreAndContinueDeployProcess
+
+		isReady := status.IsReady
+		waitingForMessages := status.WaitingForMessages
+		if spec.WaitForNextRun {
+			isReady = false
+			for _, jobStatus := range status.Jobs {
+				if jobStatus.IsSucceeded {
+					isReady = true
+				}
+			}
+			if !isReady && !status.IsFailed {
+				waitingForMessages = append(waitingForMessages, "next run to succeed")
+			}
+		}
+
+		resource := formatResourceCaption(mt.tableResourceName(spec), spec.FailMode, isReady, status.IsFailed, true)
+
+		schedule := "-"
+		if status.Schedule != "" {
+			schedule = status.Schedule
+		}
+
+		lastSchedule := "-"
+		if status.LastSchedule != "" {
+			lastSchedule = status.LastSchedule
+		}
+
+		pods := map[string]pod.PodStatus{}
+		for _, jobStatus := range status.Jobs {
+			for podName, podStatus := range jobStatus.Pods {
+				pods[podName] = podStatus
+			}
+		}
+
+		prevPods := map[string]pod.PodStatus{}
+		for _, jobStatus := range prevStatus.Jobs {
+			for podName, podStatus := range jobStatus.Pods {
+				prevPods[podName] = podStatus
+			}
+		}
+
+		args := []interface{}{resource, schedule, lastSchedule, len(status.ActiveJobsNames)}
+		if status.IsFailed {
+			args = append(args, formatResourceError(disableWarningColors, status.FailedReason))
+		} else if len(pods) == 0 && len(waitingForMessages) > 0 {
+			args = append(args, color.New(color.FgBlue).Sprintf("Waiting for: %s", strings.Join(waitingForMessages, ", ")))
+		}
+		t.Row(args...)
+
+		if len(pods) > 0 {
+			newPodsNames := []string{}
+			for podName := range pods {
+				newPodsNames = append(newPodsNames, podName)
+			}
+
+			st := mt.displayChildPodsStatusProgress(&t, prevPods, pods, newPodsNames, spec.FailMode, showProgress, disableWarningColors)
+
+			extraMsg := ""
+			if len(waitingForMessages) > 0 {
+				extraMsg += "---\n"
+				extraMsg += color.New(color.FgBlue).Sprintf("Waiting for: %s", strings.Join(waitingForMessages, ", "))
+			}
+			st.Commit(extraMsg)
+		}
+
+		mt.PrevCronJobsStatuses[name] = status
+	}
+
+	if len(resourcesNames) > 0 {
+		_, _ = logboek.OutF(t.Render())
+	}
+}
+
+func (mt *multitracker) displayPodsStatusProgress() {
+	t := utils.NewTable(statusProgressTableRatio...)
+	t.SetWidth(logboek.ContentWidth() - 1)
+	t.Header("POD", "READY", "RESTARTS", "STATUS")
+
+	resourcesNames := tableResourcesNames(mt.PodsSpecs)
+
+	for _, name := range resourcesNames {
+		prevStatus := mt.PrevPodsStatuses[name]
+		status := mt.PodsStatuses[name]
+		spec := mt.PodsSpecs[name]
+
+		showProgress := status.StatusGeneration > prevStatus.StatusGeneration
+		disableWarningColors := spec.FailMode == IgnoreAndContinueDeployProcess
+
+		resource := formatResourceCaption(mt.tableResourceName(spec), spec.FailMode, isStandalonePodReady(status), status.IsFailed, true)
+
+		ready := fmt.Sprintf("%d/%d", status.ReadyContainers, status.TotalContainers)
+
+		podStatus := "-"
+		if status.StatusIndicator != nil {
+			podStatus = status.StatusIndicator.FormatTableElem(prevStatus.StatusIndicator, indicators.FormatTableElemOptions{
+				ShowProgress:         showProgress,
+				DisableWarningColors: disableWarningColors,
+				IsResourceNew:        true,
+			})
+		}
+
+		args := []interface{}{resource, ready, status.Restarts, podStatus}
+		if status.IsFailed {
+			args = append(args, formatResourceError(disableWarningColors, status.FailedReason))
+		}
+
+		containersNames := []string{}
+		for containerName := range status.ContainersErrors {
+			containersNames = append(containersNames, containerName)
+		}
+		sort.Strings(containersNames)
+
+		for _, containerName := range containersNames {
+			args = append(args, formatResourceError(disableWarningColors, fmt.Sprintf("container/%s: %s", containerName, status.ContainersErrors[containerName])))
+		}
+
+		for _, hint := range podHints(status) {
+			args = append(args, formatResourceWarning(disableWarningColors, hint))
+		}
+
+		t.Row(args...)
+
+		mt.PrevPodsStatuses[name] = status
+	}
+
+	if len(resourcesNames) > 0 {
+		_, _ = logboek.OutF(t.Render())
+	}
+}
+
+func (mt *multitracker) displayServicesStatusProgress() {
+	t := utils.NewTable(serviceStatusProgressTableRatio...)
+	t.SetWidth(logboek.ContentWidth() - 1)
+	t.Header("SERVICE", "TYPE", "ENDPOINTS", "EXTERNAL ADDRESS")
+
+	resourcesNames := tableResourcesNames(mt.ServicesSpecs)
+
+	for _, name := range resourcesNames {
+		status := mt.ServicesStatuses[name]
+		spec := mt.ServicesSpecs[name]
+
+		disableWarningColors := spec.FailMode == IgnoreAndContinueDeployProcess
+
+		resource := formatResourceCaption(mt.tableResourceName(spec), spec.FailMode, status.IsReady, status.IsFailed, true)
+
+		serviceType := "-"
+		if status.Type != "" {
+			serviceType = string(status.Type)
+		}
+
+		endpoints := fmt.Sprintf("%d/%d", status.ReadyEndpoints, status.MinReadyEndpoints)
+
+		externalAddress := "-"
+		if len(status.ExternalAddresses) > 0 {
+			externalAddress = strings.Join(status.ExternalAddresses, "\n")
+		} else if status.Type == corev1.ServiceTypeLoadBalancer {
+			externalAddress = "<pending>"
+		}
+
+		args := []interface{}{resource, serviceType, endpoints, externalAddress}
+		if status.IsFailed {
+			args = append(args, formatResourceError(disableWarningColors, status.FailedReason))
+		} else if len(status.WaitingForMessages) > 0 {
+			args = append(args, color.New(color.FgBlue).Sprintf("Waiting for: %s", strings.Join(status.WaitingForMessages, ", ")))
+		}
+		t.Row(args...)
+
+		mt.PrevServicesStatuses[name] = status
+	}
+
+	if len(resourcesNames) > 0 {
+		_, _ = logboek.OutF(t.Render())
+	}
+}
+
+func (mt *multitracker) displayIngressesStatusProgress() {
+	t := utils.NewTable(ingressStatusProgressTableRatio...)
+	t.SetWidth(logboek.ContentWidth() - 1)
+	t.Header("INGRESS", "HOSTS", "ADDRESS", "BACKENDS")
+
+	resourcesNames := tableResourcesNames(mt.IngressesSpecs)
+
+	for _, name := range resourcesNames {
+		status := mt.IngressesStatuses[name]
+		spec := mt.IngressesSpecs[name]
+
+		disableWarningColors := spec.FailMode == IgnoreAndContinueDeployProcess
+
+		resource := formatResourceCaption(mt.tableResourceName(spec), spec.FailMode, status.IsReady, status.IsFailed, true)
+
+		hosts := "-"
+		if len(status.Hosts) > 0 {
+			hosts = strings.Join(status.Hosts, "\n")
+		}
+
+		address := "<pending>"
+		if len(status.Addresses) > 0 {
+			address = strings.Join(status.Addresses, "\n")
+		}
+
+		readyBackends := 0
+		for _, backend := range status.Backends {
+			if backend.IsReady {
+				readyBackends++
+			}
+		}
+		backends := fmt.Sprintf("%d/%d", readyBackends, len(status.Backends))
+
+		args := []interface{}{resource, hosts, address, backends}
+		if status.IsFailed {
+			args = append(args, formatResourceError(disableWarningColors, status.FailedReason))
+		} else if len(status.WaitingForMessages) > 0 {
+			args = append(args, color.New(color.FgBlue).Sprintf("Waiting for: %s", strings.Join(status.WaitingForMessages, ", ")))
+		}
+		t.Row(args...)
+
+		mt.PrevIngressesStatuses[name] = status
+	}
+
+	if len(resourcesNames) > 0 {
+		_, _ = logboek.OutF(t.Render())
+	}
+}
+
+func (mt *multitracker) displayPVCsStatusProgress() {
+	t := utils.NewTable(pvcStatusProgressTableRatio...)
+	t.SetWidth(logboek.ContentWidth() - 1)
+	t.Header("PVC", "STATUS", "CAPACITY", "STORAGECLASS")
+
+	resourcesNames := tableResourcesNames(mt.PVCsSpecs)
+
+	for _, name := range resourcesNames {
+		prevStatus := mt.PrevPVCsStatuses[name]
+		status := mt.PVCsStatuses[name]
+		spec := mt.PVCsSpecs[name]
+
+		showProgress := status.StatusGeneration > prevStatus.StatusGeneration
+		disableWarningColors := spec.FailMode == IgnoreAndContinueDeployProcess
+
+		resource := formatResourceCaption(mt.tableResourceName(spec), spec.FailMode, status.IsReady, status.IsFailed, true)
+
+		phase := "-"
+		if status.PhaseIndicator != nil {
+			phase = status.PhaseIndicator.FormatTableElem(prevStatus.PhaseIndicator, indicators.FormatTableElemOptions{
+				ShowProgress:         showProgress,
+				DisableWarningColors: disableWarningColors,
+				IsResourceNew:        true,
+			})
+		}
+
+		capacity := "-"
+		if status.Capacity != "" {
+			capacity = status.Capacity
+		}
+
+		storageClass := "-"
+		if status.StorageClass != "" {
+			storageClass = status.StorageClass
+		}
+
+		args := []interface{}{resource, phase, capacity, storageClass}
+		if status.IsFailed {
+			args = append(args, formatResourceError(disableWarningColors, status.FailedReason))
+		} else if len(status.WaitingForMessages) > 0 {
+			args = append(args, color.New(color.FgBlue).Sprintf("Waiting for: %s", strings.Join(status.WaitingForMessages, ", ")))
+		}
+		t.Row(args...)
+
+		mt.PrevPVCsStatuses[name] = status
+	}
+
+	if len(resourcesNames) > 0 {
+		_, _ = logboek.OutF(t.Render())
+	}
+}
+
+// displayChildPVCsStatusProgress shows PersistentVolumeClaims of the StatefulSet as a sub-table
+func (mt *multitracker) displayChildPVCsStatusProgress(t *utils.Table, prevPVCs map[string]pvc.PVCStatus, pvcs map[string]pvc.PVCStatus, showProgress, disableWarningColors bool) *utils.Table {
+	st := t.SubTable(pvcStatusProgressSubTableRatio...)
+	st.Header("PVC", "STATUS", "CAPACITY", "STORAGECLASS")
+
+	pvcsNames := []string{}
+	for pvcName := range pvcs {
+		pvcsNames = append(pvcsNames, pvcName)
+	}
+	sort.Strings(pvcsNames)
+
+	var pvcRows [][]interface{}
+
+	for _, pvcName := range pvcsNames {
+		prevPVCStatus := prevPVCs[pvcName]
+		pvcStatus := pvcs[pvcName]
+
+		phase := "-"
+		if pvcStatus.PhaseIndicator != nil {
+			phase = pvcStatus.PhaseIndicator.FormatTableElem(prevPVCStatus.PhaseIndicator, indicators.FormatTableElemOptions{
+				ShowProgress:         showProgress,
+				DisableWarningColors: disableWarningColors,
+				IsResourceNew:        true,
+			})
+		}
+
+		capacity := "-"
+		if pvcStatus.Capacity != "" {
+			capacity = pvcStatus.Capacity
+		}
+
+		storageClass := "-"
+		if pvcStatus.StorageClass != "" {
+			storageClass = pvcStatus.StorageClass
+		}
+
+		pvcRow := []interface{}{pvcName, phase, capacity, storageClass}
+		if pvcStatus.IsFailed {
+			pvcRow = append(pvcRow, formatResourceError(disableWarningColors, pvcStatus.FailedReason))
+		}
+
+		pvcRows = append(pvcRows, pvcRow)
+	}
+
+	st.Rows(pvcRows...)
+
+	return &st
+}
+
+func (mt *multitracker) displayRolloutsStatusProgress() {
+	t := utils.NewTable(rolloutStatusProgressTableRatio...)
+	t.SetWidth(logboek.ContentWidth() - 1)
+	t.Header("ROLLOUT", "REPLICAS", "AVAILABLE", "STEP", "WEIGHT")
+
+	resourcesNames := tableResourcesNames(mt.RolloutsSpecs)
+
+	for _, name := range resourcesNames {
+		prevStatus := mt.PrevRolloutsStatuses[name]
+		status := mt.RolloutsStatuses[name]
+		spec := mt.RolloutsSpecs[name]
+
+		showProgress := status.StatusGeneration > prevStatus.StatusGeneration
+		disableWarningColors := spec.FailMode == IgnoreAndContinueDeployProcess
+
+		resource := formatResourceCaption(mt.tableResourceName(spec), spec.FailMode, status.IsReady, status.IsFailed, true)
+
+		replicas := "-"
+		if status.ReplicasIndicator != nil {
+			replicas = status.ReplicasIndicator.FormatTableElem(prevStatus.ReplicasIndicator, indicators.FormatTableElemOptions{
+				ShowProgress:         showProgress,
+				DisableWarningColors: disableWarningColors,
+				WithTargetValue:      true,
+			})
+		}
+
+		available := "-"
+		if status.AvailableIndicator != nil {
+			available = status.AvailableIndicator.FormatTableElem(prevStatus.AvailableIndicator, indicators.FormatTableElemOptions{
+				ShowProgress:         showProgress,
+				DisableWarningColors: disableWarningColors,
+			})
+		}
+
+		step := "-"
+		if status.StepsCount > 0 {
+			step = fmt.Sprintf("%d/%d", status.CurrentStepIndex, status.StepsCount)
+		}
+		if status.IsPaused {
+			step += " (paused)"
+		}
+
+		weight := "-"
+		if status.CanaryWeight >= 0 {
+			weight = fmt.Sprintf("%d%%", status.CanaryWeight)
+		}
+
+		args := []interface{}{resource, replicas, available, step, weight}
+		if status.IsFailed {
+			args = append(args, formatResourceError(disableWarningColors, status.FailedReason))
+		}
+
+		analysisRunsNames := []string{}
+		for runName := range status.AnalysisRuns {
+			analysisRunsNames = append(analysisRunsNames, runName)
+		}
+		sort.Strings(analysisRunsNames)
+
+		for _, runName := range analysisRunsNames {
+			run := status.AnalysisRuns[runName]
+			msg := fmt.Sprintf("analysisrun/%s %s", run.Name, run.Phase)
+			if run.Message != "" {
+				msg += fmt.Sprintf(": %s", run.Message)
+			}
+
+			if run.IsFailed() {
+				args = append(args, formatResourceError(disableWarningColors, msg))
+			} else {
+				args = append(args, msg)
+			}
+		}
+
+		t.Row(args...)
+
+		if len(status.Pods) > 0 {
+			st := mt.displayChildPodsStatusProgress(&t, prevStatus.Pods, status.Pods, status.NewPodsNames, spec.FailMode, showProgress, disableWarningColors)
+			extraMsg := ""
+			if len(status.WaitingForMessages) > 0 {
+				extraMsg += "---\n"
+				extraMsg += color.New(color.FgBlue).Sprintf("Waiting for: %s", strings.Join(status.WaitingForMessages, ", "))
+			}
+			st.Commit(extraMsg)
+		}
+
+		mt.PrevRolloutsStatuses[name] = status
+	}
+
+	if len(resourcesNames) > 0 {
+		_, _ = logboek.OutF(t.Render())
+	}
+}
+
+func (mt *multitracker) displayGenericStatusProgress() {
+	t := utils.NewTable(genericStatusProgressTableRatio...)
+	t.SetWidth(logboek.ContentWidth() - 1)
+	t.Header("RESOURCE", "AGE", "CONDITIONS")
+
+	resourcesNames := tableResourcesNames(mt.GenericSpecs)
+
+	for _, name := range resourcesNames {
+		prevStatus := mt.PrevGenericStatuses[name]
+		status := mt.GenericStatuses[name]
+		spec := mt.GenericSpecs[name]
+
+		showProgress := status.StatusGeneration > prevStatus.StatusGeneration
+		disableWarningColors := spec.FailMode == IgnoreAndContinueDeployProcess
+
+		resource := formatResourceCaption(mt.fullResourceName(genericResourceKind(spec), spec), spec.FailMode, status.IsReady, status.IsFailed, true)
+
+		age := "-"
+		if status.Age != "" {
+			age = status.Age
+		}
+
+		conditions := []string{}
+		for _, c := range status.Conditions {
+			var prevIndicator *indicators.StringEqualConditionIndicator
+			for _, prevCondition := range prevStatus.Conditions {
+				if prevCondition.Type == c.Type {
+					prevIndicator = prevCondition.Indicator
+				}
+			}
+
+			value := c.Indicator.FormatTableElem(prevIndicator, indicators.FormatTableElemOptions{
+				ShowProgress:         showProgress,
+				DisableWarningColors: disableWarningColors,
+				IsResourceNew:        c.IsTracked,
+			})
+			conditions = append(conditions, fmt.Sprintf("%s=%s", c.Type, value))
+		}
+
+		conditionsElem := "-"
+		if len(conditions) > 0 {
+			conditionsElem = strings.Join(conditions, "\n")
+		}
+
+		args := []interface{}{resource, age, conditionsElem}
+		if status.IsFailed {
+			args = append(args, formatResourceError(disableWarningColors, status.FailedReason))
+		} else if len(status.WaitingForMessages) > 0 {
+			args = append(args, color.New(color.FgBlue).Sprintf("Waiting for: %s", strings.Join(status.WaitingForMessages, ", ")))
+		}
+		t.Row(args...)
+
+		mt.PrevGenericStatuses[name] = status
+	}
+
+	if len(resourcesNames) > 0 {
+		_, _ = logboek.OutF(t.Render())
+	}
+}
+
 func (mt *multitracker) displayStatefulSetsStatusProgress() {
 	t := utils.NewTable(statusProgressTableRatio...)
 	t.SetWidth(logboek.ContentWidth() - 1)
 	t.Header("STATEFULSET", "REPLICAS", "READY", "UP-TO-DATE")
 
-	resourcesNames := []string{}
-	for name := range mt.StatefulSetsSpecs {
-		resourcesNames = append(resourcesNames, name)
-	}
-	sort.Strings(resourcesNames)
+	resourcesNames := tableResourcesNames(mt.StatefulSetsSpecs)
 
 	for _, name := range resourcesNames {
 		prevStatus := mt.PrevStatefulSetsStatuses[name]
@@ -314,7 +998,7 @@ func (mt *multitracker) displayStatefulSetsStatusProgress() {
 		showProgress := status.StatusGeneration > prevStatus.StatusGeneration
 		disableWarningColors := spec.FailMode == IgnoreAndContinueDeployProcess
 
-		resource := formatResourceCaption(name, spec.FailMode, status.IsReady, status.IsFailed, true)
+		resource := formatResourceCaption(mt.tableResourceName(spec), spec.FailMode, status.IsReady, status.IsFailed, true)
 
 		replicas := "-"
 		if status.ReplicasIndicator != nil {
@@ -362,6 +1046,11 @@ func (mt *multitracker) displayStatefulSetsStatusProgress() {
 			st.Commit(extraMsg)
 		}
 
+		if len(status.PVCs) > 0 {
+			st := mt.displayChildPVCsStatusProgress(&t, prevStatus.PVCs, status.PVCs, showProgress, disableWarningColors)
+			st.Commit()
+		}
+
 		mt.PrevStatefulSetsStatuses[name] = status
 	}
 
@@ -375,11 +1064,7 @@ func (mt *multitracker) displayDaemonSetsStatusProgress() {
 	t.SetWidth(logboek.ContentWidth() - 1)
 	t.Header("DAEMONSET", "REPLICAS", "AVAILABLE", "UP-TO-DATE")
 
-	resourcesNames := []string{}
-	for name := range mt.DaemonSetsSpecs {
-		resourcesNames = append(resourcesNames, name)
-	}
-	sort.Strings(resourcesNames)
+	resourcesNames := tableResourcesNames(mt.DaemonSetsSpecs)
 
 	for _, name := range resourcesNames {
 		prevStatus := mt.PrevDaemonSetsStatuses[name]
@@ -390,7 +1075,7 @@ func (mt *multitracker) displayDaemonSetsStatusProgress() {
 		showProgress := status.StatusGeneration > prevStatus.StatusGeneration
 		disableWarningColors := spec.FailMode == IgnoreAndContinueDeployProcess
 
-		resource := formatResourceCaption(name, spec.FailMode, status.IsReady, status.IsFailed, true)
+		resource := formatResourceCaption(mt.tableResourceName(spec), spec.FailMode, status.IsReady, status.IsFailed, true)
 
 		replicas := "-"
 		if status.ReplicasIndicator != nil {
@@ -446,11 +1131,7 @@ func (mt *multitracker) displayDeploymentsStatusProgress() {
 	t.SetWidth(logboek.ContentWidth() - 1)
 	t.Header("DEPLOYMENT", "REPLICAS", "AVAILABLE", "UP-TO-DATE")
 
-	resourcesNames := []string{}
-	for name := range mt.DeploymentsSpecs {
-		resourcesNames = append(resourcesNames, name)
-	}
-	sort.Strings(resourcesNames)
+	resourcesNames := tableResourcesNames(mt.DeploymentsSpecs)
 
 	for _, name := range resourcesNames {
 		prevStatus := mt.PrevDeploymentsStatuses[name]
@@ -460,7 +1141,7 @@ func (mt *multitracker) displayDeploymentsStatusProgress() {
 		showProgress := status.StatusGeneration > prevStatus.StatusGeneration
 		disableWarningColors := spec.FailMode == IgnoreAndContinueDeployProcess
 
-		resource := formatResourceCaption(name, spec.FailMode, status.IsReady, status.IsFailed, true)
+		resource := formatResourceCaption(mt.tableResourceName(spec), spec.FailMode, status.IsReady, status.IsFailed, true)
 
 		replicas := "-"
 		if status.ReplicasIndicator != nil {
@@ -493,6 +1174,11 @@ func (mt *multitracker) displayDeploymentsStatusProgress() {
 			t.Row(resource, replicas, available, uptodate)
 		}
 
+		if status.HPA != nil {
+			st := mt.displayChildHPAStatusProgress(&t, status.HPA, disableWarningColors)
+			st.Commit()
+		}
+
 		if len(status.Pods) > 0 {
 			st := mt.displayChildPodsStatusProgress(&t, prevStatus.Pods, status.Pods, status.NewPodsNames, spec.FailMode, showProgress, disableWarningColors)
 			extraMsg := ""
@@ -511,6 +1197,26 @@ func (mt *multitracker) displayDeploymentsStatusProgress() {
 	}
 }
 
+// displayChildHPAStatusProgress shows HorizontalPodAutoscaler of the Deployment as a sub-table
+func (mt *multitracker) displayChildHPAStatusProgress(t *utils.Table, hpa *deployment.HPAStatus, disableWarningColors bool) *utils.Table {
+	st := t.SubTable(statusProgressSubTableRatio...)
+	st.Header("HPA", "CURRENT", "DESIRED", "MIN/MAX")
+
+	hpaRow := []interface{}{
+		hpa.Name,
+		fmt.Sprintf("%d", hpa.CurrentReplicas),
+		fmt.Sprintf("%d", hpa.DesiredReplicas),
+		fmt.Sprintf("%d/%d", hpa.MinReplicas, hpa.MaxReplicas),
+	}
+	for _, warning := range hpa.Warnings {
+		hpaRow = append(hpaRow, formatResourceWarning(disableWarningColors, warning))
+	}
+
+	st.Row(hpaRow...)
+
+	return &st
+}
+
 func (mt *multitracker) displayChildPodsStatusProgress(t *utils.Table, prevPods map[string]pod.PodStatus, pods map[string]pod.PodStatus, newPodsNames []string, failMode FailMode, showProgress, disableWarningColors bool) *utils.Table {
 	st := t.SubTable(statusProgressSubTableRatio...)
 	st.Header("POD", "READY", "RESTARTS", "STATUS")
@@ -558,6 +1264,9 @@ func (mt *multitracker) displayChildPodsStatusProgress(t *utils.Table, prevPods
 		if podStatus.IsFailed {
 			podRow = append(podRow, formatResourceError(disableWarningColors, podStatus.FailedReason))
 		}
+		for _, hint := range podHints(podStatus) {
+			podRow = append(podRow, formatResourceWarning(disableWarningColors, hint))
+		}
 
 		podRows = append(podRows, podRow)
 	}
@@ -618,5 +1327,5 @@ func formatResourceCaption(resourceCaption string, resourceFailMode FailMode, is
 }
 
 func podContainerLogChunkHeader(podName string, chunk *pod.ContainerLogChunk) string {
-	return fmt.Sprintf("po/%s container/%s", podName, chunk.ContainerName)
+	return fmt.Sprintf("po/%s container/%s", podName, chunk.Label())
 }
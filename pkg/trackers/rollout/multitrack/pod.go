@@ -0,0 +1,166 @@
+package multitrack
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/flant/kubedog/pkg/tracker/pod"
+)
+
+// isStandalonePodReady returns true when the pod has reached its target state:
+// pods with restartPolicy Always should become ready, other pods should succeed.
+func isStandalonePodReady(status pod.PodStatus) bool {
+	if status.IsSucceeded {
+		return true
+	}
+	return isPodReadyRequired(status) && status.IsReady
+}
+
+func isPodReadyRequired(status pod.PodStatus) bool {
+	return status.RestartPolicy == corev1.RestartPolicyAlways || status.RestartPolicy == ""
+}
+
+func (mt *multitracker) TrackPod(kube kubernetes.Interface, spec MultitrackSpec, opts MultitrackOptions) error {
+	feed := pod.NewFeed()
+
+	feed.OnAdded(func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PodsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.podAdded(spec, feed)
+	})
+	feed.OnReady(func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PodsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.podReady(spec, feed)
+	})
+	feed.OnSucceeded(func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PodsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.podSucceeded(spec, feed)
+	})
+	feed.OnFailed(func(reason string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PodsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.podFailed(spec, feed, reason)
+	})
+	feed.OnEventMsg(func(msg string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PodsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.podEventMsg(spec, feed, msg)
+	})
+	feed.OnContainerLogChunk(func(chunk *pod.ContainerLogChunk) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PodsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.podContainerLogChunk(spec, feed, chunk)
+	})
+	feed.OnContainerError(func(containerError pod.ContainerError) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PodsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.podContainerError(spec, feed, containerError)
+	})
+	feed.OnStatus(func(status pod.PodStatus) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PodsStatuses[resourceKey(spec)] = status
+
+		if !status.IsFailed && len(status.ContainersErrors) == 0 {
+			mt.resetResourceFailure(mt.TrackingPods, spec)
+		}
+
+		return mt.handleResourceReadyStability(mt.TrackingPods, "po", spec, status.IsReady || status.IsSucceeded, status.Restarts)
+	})
+
+	return feed.Track(spec.ResourceName, spec.Namespace, kube, opts.Options)
+}
+
+func (mt *multitracker) podAdded(spec MultitrackSpec, feed pod.Feed) error {
+	mt.handleResourceAdded(mt.TrackingPods, spec)
+
+	mt.displayResourceTrackerMessageF("po", spec, "added")
+	mt.emitResourceEvent("po", spec, Event{Type: ResourceAddedEvent})
+
+	return nil
+}
+
+func (mt *multitracker) podReady(spec MultitrackSpec, feed pod.Feed) error {
+	// Pod which is already ready, succeeded or failed is reported without being added first
+	mt.handleResourceAdded(mt.TrackingPods, spec)
+
+	mt.displayResourceTrackerMessageF("po", spec, "become READY")
+
+	if !isPodReadyRequired(feed.GetStatus()) {
+		// Pod with restartPolicy Never or OnFailure is done only when succeeded
+		return nil
+	}
+
+	mt.emitResourceEvent("po", spec, Event{Type: ResourceReadyEvent})
+
+	return mt.handleResourceReadyCondition(mt.TrackingPods, spec)
+}
+
+func (mt *multitracker) podSucceeded(spec MultitrackSpec, feed pod.Feed) error {
+	mt.handleResourceAdded(mt.TrackingPods, spec)
+
+	mt.displayResourceTrackerMessageF("po", spec, "succeeded")
+	mt.emitResourceEvent("po", spec, Event{Type: ResourceReadyEvent})
+
+	return mt.handleResourceReadyCondition(mt.TrackingPods, spec)
+}
+
+func (mt *multitracker) podFailed(spec MultitrackSpec, feed pod.Feed, reason string) error {
+	mt.handleResourceAdded(mt.TrackingPods, spec)
+
+	mt.displayResourceErrorF("po", spec, "%s", reason)
+	mt.emitResourceEvent("po", spec, Event{Type: ResourceFailedEvent, Message: reason})
+
+	return mt.handleResourceFailure(mt.TrackingPods, "po", spec, reason)
+}
+
+func (mt *multitracker) podEventMsg(spec MultitrackSpec, feed pod.Feed, msg string) error {
+	mt.displayResourceEventF("po", spec, "%s", msg)
+	return nil
+}
+
+func (mt *multitracker) podContainerLogChunk(spec MultitrackSpec, feed pod.Feed, chunk *pod.ContainerLogChunk) error {
+	status := mt.PodsStatuses[resourceKey(spec)]
+	if !isPodLogsVisible(spec, map[string]pod.PodStatus{spec.ResourceName: status}, spec.ResourceName) {
+		return nil
+	}
+
+	mt.displayResourceLogChunk("po", spec, spec.ResourceName, fmt.Sprintf("container/%s", chunk.Label()), chunk)
+	return nil
+}
+
+func (mt *multitracker) podContainerError(spec MultitrackSpec, feed pod.Feed, containerError pod.ContainerError) error {
+	reason := fmt.Sprintf("container/%s: %s", containerError.ContainerName, containerError.Message)
+	reason = withContainerFailureHint(reason, map[string]pod.PodStatus{spec.ResourceName: mt.PodsStatuses[resourceKey(spec)]}, spec.ResourceName, containerError.ContainerName)
+
+	mt.displayResourceErrorF("po", spec, "%s", reason)
+	mt.emitResourceEvent("po", spec, Event{Type: ContainerErrorEvent, Pod: spec.ResourceName, Container: containerError.ContainerName, Message: containerError.Message})
+
+	return mt.handleResourceFailure(mt.TrackingPods, "po", spec, reason)
+}
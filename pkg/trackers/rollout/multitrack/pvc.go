@@ -0,0 +1,93 @@
+package multitrack
+
+import (
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/flant/kubedog/pkg/tracker/pvc"
+)
+
+func (mt *multitracker) TrackPVC(kube kubernetes.Interface, spec MultitrackSpec, opts MultitrackOptions) error {
+	feed := pvc.NewFeed()
+
+	feed.OnAdded(func(isReady bool) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PVCsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.pvcAdded(spec, feed, isReady)
+	})
+	feed.OnReady(func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PVCsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.pvcReady(spec, feed)
+	})
+	feed.OnFailed(func(reason string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PVCsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.pvcFailed(spec, feed, reason)
+	})
+	feed.OnEventMsg(func(msg string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PVCsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.pvcEventMsg(spec, feed, msg)
+	})
+	feed.OnStatus(func(status pvc.PVCStatus) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.PVCsStatuses[resourceKey(spec)] = status
+
+		if !status.IsFailed {
+			mt.resetResourceFailure(mt.TrackingPVCs, spec)
+		}
+
+		return mt.handleResourceReadyStability(mt.TrackingPVCs, "pvc", spec, status.IsReady, 0)
+	})
+
+	return feed.Track(spec.ResourceName, spec.Namespace, kube, opts.Options)
+}
+
+func (mt *multitracker) pvcAdded(spec MultitrackSpec, feed pvc.Feed, isReady bool) error {
+	mt.handleResourceAdded(mt.TrackingPVCs, spec)
+
+	if isReady {
+		mt.displayResourceTrackerMessageF("pvc", spec, "appears to be READY")
+		mt.emitResourceEvent("pvc", spec, Event{Type: ResourceReadyEvent})
+
+		return mt.handleResourceReadyCondition(mt.TrackingPVCs, spec)
+	}
+
+	mt.displayResourceTrackerMessageF("pvc", spec, "added")
+	mt.emitResourceEvent("pvc", spec, Event{Type: ResourceAddedEvent})
+
+	return nil
+}
+
+func (mt *multitracker) pvcReady(spec MultitrackSpec, feed pvc.Feed) error {
+	mt.displayResourceTrackerMessageF("pvc", spec, "become READY")
+	mt.emitResourceEvent("pvc", spec, Event{Type: ResourceReadyEvent})
+
+	return mt.handleResourceReadyCondition(mt.TrackingPVCs, spec)
+}
+
+func (mt *multitracker) pvcFailed(spec MultitrackSpec, feed pvc.Feed, reason string) error {
+	mt.displayResourceErrorF("pvc", spec, "%s", reason)
+	mt.emitResourceEvent("pvc", spec, Event{Type: ResourceFailedEvent, Message: reason})
+
+	return mt.handleResourceFailure(mt.TrackingPVCs, "pvc", spec, reason)
+}
+
+func (mt *multitracker) pvcEventMsg(spec MultitrackSpec, feed pvc.Feed, msg string) error {
+	mt.displayResourceEventF("pvc", spec, "%s", msg)
+	return nil
+}
@@ -0,0 +1,119 @@
+package multitrack
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// MultitrackResult is the outcome of tracking of all resources
+type MultitrackResult struct {
+	Resources []ResourceResult
+}
+
+type ResourceResult struct {
+	Kind      string
+	Namespace string
+	Name      string
+
+	// State is Succeeded, Failed or TimedOut for done resources,
+	// Active, Hoping or ActiveAfterHoping for resources, which were not done when tracking has been stopped
+	State string
+	// ReadyDuration is the time from the resource is added to the resource is ready, 0 if the resource is not ready
+	ReadyDuration time.Duration
+	FailuresCount int
+	FailedReason  string
+	// Restarts is the number of restarts of the resource pods seen during tracking
+	Restarts int32
+
+	// Status is the last status snapshot of the resource, the same as in the Status event
+	Status *EventStatus
+}
+
+// IsFailed returns true if any of the resources is failed or timed out
+func (r MultitrackResult) IsFailed() bool {
+	for _, res := range r.Resources {
+		if res.State == "Failed" || res.State == "TimedOut" {
+			return true
+		}
+	}
+	return false
+}
+
+func (mt *multitracker) result() MultitrackResult {
+	result := MultitrackResult{}
+
+	for _, k := range mt.trackedKinds() {
+		for _, name := range sortedResourcesNames(k.States) {
+			result.Resources = append(result.Resources, mt.resourceResult(k, k.Specs[name]))
+		}
+	}
+
+	return result
+}
+
+func (mt *multitracker) resourceResult(k multitrackerKind, spec MultitrackSpec) ResourceResult {
+	state := k.States[resourceKey(spec)]
+
+	res := ResourceResult{
+		Kind:          k.ResourceKind(spec),
+		Namespace:     spec.Namespace,
+		Name:          spec.ResourceName,
+		State:         strings.TrimPrefix(string(state.Status), "resource"),
+		FailuresCount: state.FailuresCount,
+		FailedReason:  state.FailedReason,
+		Restarts:      mt.resourceRestartsCount(k, spec),
+		Status:        mt.resourceEventStatus(k, spec),
+	}
+	if !state.ReadyAt.IsZero() {
+		res.ReadyDuration = resourceDuration(state, state.ReadyAt)
+	}
+
+	return res
+}
+
+func sortedResourcesNames(states map[string]*multitrackerResourceState) []string {
+	var names []string
+	for name := range states {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resourceRestartsCount returns restarts count of the new pods of the resource accordingly to its last status
+func (mt *multitracker) resourceRestartsCount(k multitrackerKind, spec MultitrackSpec) int32 {
+	if spec.TrackTerminationMode == WaitUntilResourceDeleted {
+		return 0
+	}
+
+	key := resourceKey(spec)
+
+	switch k.Kind {
+	case "deploy":
+		status := mt.DeploymentsStatuses[key]
+		return podsRestartsCount(status.Pods, status.NewPodsNames)
+	case "sts":
+		status := mt.StatefulSetsStatuses[key]
+		return podsRestartsCount(status.Pods, status.NewPodsNames)
+	case "ds":
+		status := mt.DaemonSetsStatuses[key]
+		return podsRestartsCount(status.Pods, status.NewPodsNames)
+	case "job":
+		status := mt.JobsStatuses[key]
+		return podsRestartsCount(status.Pods, podsNames(status.Pods))
+	case "cronjob":
+		var count int32
+		for _, jobStatus := range mt.CronJobsStatuses[key].Jobs {
+			count += podsRestartsCount(jobStatus.Pods, podsNames(jobStatus.Pods))
+		}
+		return count
+	case "po":
+		return mt.PodsStatuses[key].Restarts
+	case "rollout":
+		status := mt.RolloutsStatuses[key]
+		return podsRestartsCount(status.Pods, status.NewPodsNames)
+	}
+
+	return 0
+}
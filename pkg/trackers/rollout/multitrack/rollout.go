@@ -0,0 +1,190 @@
+package multitrack
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/flant/kubedog/pkg/tracker/argorollout"
+	"github.com/flant/kubedog/pkg/tracker/replicaset"
+)
+
+// rolloutReplicaSetRole returns role of the ReplicaSet in the Rollout for user messages
+func rolloutReplicaSetRole(status argorollout.RolloutStatus, rs replicaset.ReplicaSet) string {
+	switch {
+	case rs.Name == status.StableReplicaSetName:
+		return "stable"
+	case status.Strategy == argorollout.BlueGreenStrategy:
+		return "preview"
+	default:
+		return "canary"
+	}
+}
+
+func (mt *multitracker) TrackRollout(kube kubernetes.Interface, dynamicClient dynamic.Interface, spec MultitrackSpec, opts MultitrackOptions) error {
+	feed := argorollout.NewFeed()
+
+	feed.OnAdded(func(isReady bool) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.RolloutsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.rolloutAdded(spec, feed, isReady)
+	})
+	feed.OnReady(func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.RolloutsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.rolloutReady(spec, feed)
+	})
+	feed.OnFailed(func(reason string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.RolloutsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.rolloutFailed(spec, feed, reason)
+	})
+	feed.OnEventMsg(func(msg string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.RolloutsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.rolloutEventMsg(spec, feed, msg)
+	})
+	feed.OnAddedReplicaSet(func(rs replicaset.ReplicaSet) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.RolloutsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.rolloutAddedReplicaSet(spec, feed, rs)
+	})
+	feed.OnAddedPod(func(pod replicaset.ReplicaSetPod) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.RolloutsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.rolloutAddedPod(spec, feed, pod)
+	})
+	feed.OnPodError(func(podError replicaset.ReplicaSetPodError) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.RolloutsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.rolloutPodError(spec, feed, podError)
+	})
+	feed.OnPodLogChunk(func(chunk *replicaset.ReplicaSetPodLogChunk) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.RolloutsStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.rolloutPodLogChunk(spec, feed, chunk)
+	})
+	feed.OnStatus(func(status argorollout.RolloutStatus) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.RolloutsStatuses[resourceKey(spec)] = status
+
+		if !status.IsFailed && !hasPodsErrors(status.Pods, status.NewPodsNames) {
+			mt.resetResourceFailure(mt.TrackingRollouts, spec)
+		}
+
+		return mt.handleResourceReadyStability(mt.TrackingRollouts, "rollout", spec, status.IsReady, podsRestartsCount(status.Pods, status.NewPodsNames))
+	})
+
+	return feed.Track(spec.ResourceName, spec.Namespace, kube, dynamicClient, opts.Options)
+}
+
+func (mt *multitracker) rolloutAdded(spec MultitrackSpec, feed argorollout.Feed, isReady bool) error {
+	mt.handleResourceAdded(mt.TrackingRollouts, spec)
+
+	if isReady {
+		mt.displayResourceTrackerMessageF("rollout", spec, "appears to be READY")
+		mt.emitResourceEvent("rollout", spec, Event{Type: ResourceReadyEvent})
+
+		return mt.handleResourceReadyCondition(mt.TrackingRollouts, spec)
+	}
+
+	mt.displayResourceTrackerMessageF("rollout", spec, "added")
+	mt.emitResourceEvent("rollout", spec, Event{Type: ResourceAddedEvent})
+
+	return nil
+}
+
+func (mt *multitracker) rolloutReady(spec MultitrackSpec, feed argorollout.Feed) error {
+	mt.displayResourceTrackerMessageF("rollout", spec, "become READY")
+	mt.emitResourceEvent("rollout", spec, Event{Type: ResourceReadyEvent})
+
+	return mt.handleResourceReadyCondition(mt.TrackingRollouts, spec)
+}
+
+func (mt *multitracker) rolloutFailed(spec MultitrackSpec, feed argorollout.Feed, reason string) error {
+	mt.displayResourceErrorF("rollout", spec, "%s", reason)
+	mt.emitResourceEvent("rollout", spec, Event{Type: ResourceFailedEvent, Message: reason})
+
+	return mt.handleResourceFailure(mt.TrackingRollouts, "rollout", spec, reason)
+}
+
+func (mt *multitracker) rolloutEventMsg(spec MultitrackSpec, feed argorollout.Feed, msg string) error {
+	mt.displayResourceEventF("rollout", spec, "%s", msg)
+	return nil
+}
+
+func (mt *multitracker) rolloutAddedReplicaSet(spec MultitrackSpec, feed argorollout.Feed, rs replicaset.ReplicaSet) error {
+	if !rs.IsNew {
+		return nil
+	}
+
+	status := mt.RolloutsStatuses[resourceKey(spec)]
+	mt.displayResourceTrackerMessageF("rollout", spec, "rs/%s (%s) added", rs.Name, rolloutReplicaSetRole(status, rs))
+
+	return nil
+}
+
+func (mt *multitracker) rolloutAddedPod(spec MultitrackSpec, feed argorollout.Feed, pod replicaset.ReplicaSetPod) error {
+	if !pod.ReplicaSet.IsNew {
+		return nil
+	}
+
+	mt.displayResourceTrackerMessageF("rollout", spec, "po/%s added", pod.Name)
+	mt.emitResourceEvent("rollout", spec, Event{Type: PodAddedEvent, Pod: pod.Name})
+
+	return nil
+}
+
+func (mt *multitracker) rolloutPodError(spec MultitrackSpec, feed argorollout.Feed, podError replicaset.ReplicaSetPodError) error {
+	if !podError.ReplicaSet.IsNew {
+		return nil
+	}
+
+	reason := fmt.Sprintf("po/%s container/%s: %s", podError.PodName, podError.ContainerName, podError.Message)
+	reason = withContainerFailureHint(reason, mt.RolloutsStatuses[resourceKey(spec)].Pods, podError.PodName, podError.ContainerName)
+
+	mt.displayResourceErrorF("rollout", spec, "%s", reason)
+	mt.emitResourceEvent("rollout", spec, Event{Type: ContainerErrorEvent, Pod: podError.PodName, Container: podError.ContainerName, Message: podError.Message})
+
+	return mt.handleResourceFailure(mt.TrackingRollouts, "rollout", spec, reason)
+}
+
+// rolloutPodLogChunk shows logs of both canary and stable Pods, tracker skips logs of older revisions
+func (mt *multitracker) rolloutPodLogChunk(spec MultitrackSpec, feed argorollout.Feed, chunk *replicaset.ReplicaSetPodLogChunk) error {
+	status := mt.RolloutsStatuses[resourceKey(spec)]
+	if !isPodLogsVisible(spec, status.Pods, chunk.PodName) {
+		return nil
+	}
+
+	header := fmt.Sprintf("%s (%s)", podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk), rolloutReplicaSetRole(status, chunk.ReplicaSet))
+	mt.displayResourceLogChunk("rollout", spec, chunk.PodName, header, chunk.ContainerLogChunk)
+
+	return nil
+}
@@ -0,0 +1,279 @@
+package multitrack
+
+import (
+	"fmt"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	"github.com/flant/kubedog/pkg/tracker"
+)
+
+// selectorKinds are kinds of resources, which could be tracked by the label selector, map by the Kind of the spec
+var selectorKinds = map[string]string{
+	"Deployment":  "deploy",
+	"StatefulSet": "sts",
+	"DaemonSet":   "ds",
+	"Job":         "job",
+}
+
+// selectorSpecKinds returns short kinds of resources matched by the selector spec:
+// all supported kinds when the Kind is not specified.
+func selectorSpecKinds(spec MultitrackSpec) []string {
+	if spec.Kind != "" {
+		return []string{selectorKinds[spec.Kind]}
+	}
+	return []string{"deploy", "sts", "ds", "job"}
+}
+
+func validateSelectorSpec(spec MultitrackSpec) error {
+	if spec.ResourceName != "" {
+		return fmt.Errorf("ResourceName should not be specified along with LabelSelector")
+	}
+	if spec.LabelSelector == "" {
+		return fmt.Errorf("LabelSelector is not specified")
+	}
+	if _, err := labels.Parse(spec.LabelSelector); err != nil {
+		return fmt.Errorf("bad LabelSelector %q: %s", spec.LabelSelector, err)
+	}
+	if _, isKnown := selectorKinds[spec.Kind]; spec.Kind != "" && !isKnown {
+		return fmt.Errorf("unsupported Kind %q: expected Deployment, StatefulSet, DaemonSet or Job", spec.Kind)
+	}
+	if spec.TrackTerminationMode == WaitUntilResourceDeleted {
+		return fmt.Errorf("%s mode is not supported for LabelSelector", WaitUntilResourceDeleted)
+	}
+	return validateSpecModes(spec)
+}
+
+func selectorID(kind string, spec MultitrackSpec) string {
+	return fmt.Sprintf("%s/%s/%s", spec.Namespace, kind, spec.LabelSelector)
+}
+
+// selectedResourceSpec makes spec of the resource matched by the selector spec
+func selectedResourceSpec(kind string, selectorSpec MultitrackSpec, name string) MultitrackSpec {
+	spec := selectorSpec
+	spec.ResourceName = name
+	spec.LabelSelector = ""
+	spec.Kind = ""
+
+	// The same defaults as for the Jobs specified by name
+	if kind == "job" && spec.ShowLogsUntil == "" {
+		spec.ShowLogsUntil = ControllerIsReady
+	}
+	setDefaultSpecValues(&spec)
+	if kind == "job" {
+		spec.ReadyStabilitySeconds = new(int)
+	}
+
+	return spec
+}
+
+// runSelectorTracker watches for resources of the kind matched by the label selector of the spec
+// and starts tracking of each matched resource, including resources created during tracking.
+func (mt *multitracker) runSelectorTracker(kind string, spec MultitrackSpec, mtCtx *multitrackerContext, wg *sync.WaitGroup, doneChan chan struct{}, errorChan chan error) {
+	defer wg.Done()
+
+	id := selectorID(kind, spec)
+
+	lw, objType := mt.selectorListWatch(kind, spec)
+
+	handleObject := func(object runtime.Object) error {
+		accessor, err := meta.Accessor(object)
+		if err != nil {
+			return err
+		}
+
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.startSelectedResource(kind, spec, accessor.GetName(), wg, doneChan, errorChan)
+
+		return nil
+	}
+
+	precondition := func(store cache.Store) (bool, error) {
+		for _, object := range store.List() {
+			if err := handleObject(object.(runtime.Object)); err != nil {
+				return true, err
+			}
+		}
+
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.SyncedSelectors[id] = true
+
+		// There could be no matched resources, so that nothing else will apply termination mode
+		if err := mt.applyTrackTerminationMode(); err != nil {
+			return true, fmt.Errorf("unable to apply termination mode: %s", err)
+		}
+
+		return false, nil
+	}
+
+	_, err := watchtools.UntilWithSync(mtCtx.Context, lw, objType, precondition, func(e watch.Event) (bool, error) {
+		if debug() {
+			fmt.Printf("%s selector informer event: %#v\n", id, e.Type)
+		}
+
+		if e.Type == watch.Error {
+			return true, fmt.Errorf("%s selector watch error: %v", id, e.Object)
+		}
+
+		// Resource could start to match the selector after labels modification
+		if e.Type == watch.Added || e.Type == watch.Modified {
+			return false, handleObject(e.Object)
+		}
+
+		return false, nil
+	})
+
+	mt.mux.Lock()
+	defer mt.mux.Unlock()
+
+	delete(mt.SelectorsContexts, id)
+
+	if err := tracker.AdaptInformerError(err); err != nil && mtCtx.Context.Err() == nil {
+		errorChan <- fmt.Errorf("%s selector track failed: %s", id, err)
+		mt.isFailed = true
+	}
+}
+
+func (mt *multitracker) selectorListWatch(kind string, spec MultitrackSpec) (*cache.ListWatch, runtime.Object) {
+	tweakListOptions := func(options metav1.ListOptions) metav1.ListOptions {
+		options.LabelSelector = spec.LabelSelector
+		return options
+	}
+
+	switch kind {
+	case "deploy":
+		client := mt.kube.AppsV1().Deployments(spec.Namespace)
+		return &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.List(tweakListOptions(options))
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.Watch(tweakListOptions(options))
+			},
+		}, &appsv1.Deployment{}
+
+	case "sts":
+		client := mt.kube.AppsV1().StatefulSets(spec.Namespace)
+		return &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.List(tweakListOptions(options))
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.Watch(tweakListOptions(options))
+			},
+		}, &appsv1.StatefulSet{}
+
+	case "ds":
+		client := mt.kube.AppsV1().DaemonSets(spec.Namespace)
+		return &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.List(tweakListOptions(options))
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.Watch(tweakListOptions(options))
+			},
+		}, &appsv1.DaemonSet{}
+
+	case "job":
+		client := mt.kube.BatchV1().Jobs(spec.Namespace)
+		return &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.List(tweakListOptions(options))
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.Watch(tweakListOptions(options))
+			},
+		}, &batchv1.Job{}
+
+	default:
+		panic(fmt.Sprintf("unsupported selector kind %q", kind))
+	}
+}
+
+// startSelectedResource starts tracking of the resource matched by the selector spec,
+// resources which are already tracked are skipped. Should be called under the multitracker lock.
+func (mt *multitracker) startSelectedResource(kind string, selectorSpec MultitrackSpec, name string, wg *sync.WaitGroup, doneChan chan struct{}, errorChan chan error) {
+	if mt.isTerminating || mt.isFailed {
+		return
+	}
+
+	spec := selectedResourceSpec(kind, selectorSpec, name)
+	opts := mt.opts
+
+	for _, k := range mt.trackedKinds() {
+		if k.Kind != kind {
+			continue
+		}
+		if _, hasKey := k.Specs[resourceKey(spec)]; hasKey {
+			return
+		}
+	}
+
+	for _, ref := range spec.DependsOn {
+		// References have been validated before tracking is started
+		if depID, err := dependencyRefToID(ref, spec.Namespace); err == nil {
+			mt.dependencies[resourceID(kind, spec)] = appendElemIfNotExist(mt.dependencies[resourceID(kind, spec)], depID)
+		}
+	}
+
+	mt.displayResourceTrackerMessageF(kind, spec, "matched by selector %q", selectorSpec.LabelSelector)
+
+	switch kind {
+	case "deploy":
+		mt.DeploymentsContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.DeploymentsSpecs[resourceKey(spec)] = spec
+		mt.TrackingDeployments[resourceKey(spec)] = newMultitrackerResourceState(spec)
+
+		wg.Add(1)
+
+		go mt.runSpecTracker("deploy", spec, mt.DeploymentsContexts[resourceKey(spec)], wg, mt.DeploymentsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+			return mt.TrackDeployment(mt.kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
+		})
+
+	case "sts":
+		mt.StatefulSetsContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.StatefulSetsSpecs[resourceKey(spec)] = spec
+		mt.TrackingStatefulSets[resourceKey(spec)] = newMultitrackerResourceState(spec)
+
+		wg.Add(1)
+
+		go mt.runSpecTracker("sts", spec, mt.StatefulSetsContexts[resourceKey(spec)], wg, mt.StatefulSetsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+			return mt.TrackStatefulSet(mt.kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
+		})
+
+	case "ds":
+		mt.DaemonSetsContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.DaemonSetsSpecs[resourceKey(spec)] = spec
+		mt.TrackingDaemonSets[resourceKey(spec)] = newMultitrackerResourceState(spec)
+
+		wg.Add(1)
+
+		go mt.runSpecTracker("ds", spec, mt.DaemonSetsContexts[resourceKey(spec)], wg, mt.DaemonSetsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+			return mt.TrackDaemonSet(mt.kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
+		})
+
+	case "job":
+		mt.JobsContexts[resourceKey(spec)] = newMultitrackerContext(opts.ParentContext)
+		mt.JobsSpecs[resourceKey(spec)] = spec
+		mt.TrackingJobs[resourceKey(spec)] = newMultitrackerResourceState(spec)
+
+		wg.Add(1)
+
+		go mt.runSpecTracker("job", spec, mt.JobsContexts[resourceKey(spec)], wg, mt.JobsContexts, doneChan, errorChan, func(spec MultitrackSpec, mtCtx *multitrackerContext) error {
+			return mt.TrackJob(mt.kube, spec, newMultitrackOptions(mtCtx.Context, opts.Timeout, opts.StatusProgressPeriod, opts.LogsFromTime))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package multitrack
+
+import (
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/flant/kubedog/pkg/tracker/service"
+)
+
+// serviceRequirements returns readiness requirements of the Service spec
+func serviceRequirements(spec MultitrackSpec) service.Requirements {
+	return service.Requirements{
+		MinReadyEndpoints: spec.MinReadyEndpoints,
+		UseEndpointSlices: spec.UseEndpointSlices,
+	}
+}
+
+func (mt *multitracker) TrackService(kube kubernetes.Interface, spec MultitrackSpec, opts MultitrackOptions) error {
+	feed := service.NewFeed()
+
+	feed.OnAdded(func(isReady bool) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.ServicesStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.serviceAdded(spec, feed, isReady)
+	})
+	feed.OnReady(func() error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.ServicesStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.serviceReady(spec, feed)
+	})
+	feed.OnFailed(func(reason string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.ServicesStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.serviceFailed(spec, feed, reason)
+	})
+	feed.OnEventMsg(func(msg string) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.ServicesStatuses[resourceKey(spec)] = feed.GetStatus()
+
+		return mt.serviceEventMsg(spec, feed, msg)
+	})
+	feed.OnStatus(func(status service.ServiceStatus) error {
+		mt.mux.Lock()
+		defer mt.mux.Unlock()
+
+		mt.ServicesStatuses[resourceKey(spec)] = status
+
+		if !status.IsFailed {
+			mt.resetResourceFailure(mt.TrackingServices, spec)
+		}
+
+		return mt.handleResourceReadyStability(mt.TrackingServices, "svc", spec, status.IsReady, 0)
+	})
+
+	return feed.Track(spec.ResourceName, spec.Namespace, serviceRequirements(spec), kube, opts.Options)
+}
+
+func (mt *multitracker) serviceAdded(spec MultitrackSpec, feed service.Feed, isReady bool) error {
+	mt.handleResourceAdded(mt.TrackingServices, spec)
+
+	if isReady {
+		mt.displayResourceTrackerMessageF("svc", spec, "appears to be READY")
+		mt.emitResourceEvent("svc", spec, Event{Type: ResourceReadyEvent})
+
+		return mt.handleResourceReadyCondition(mt.TrackingServices, spec)
+	}
+
+	mt.displayResourceTrackerMessageF("svc", spec, "added")
+	mt.emitResourceEvent("svc", spec, Event{Type: ResourceAddedEvent})
+
+	return nil
+}
+
+func (mt *multitracker) serviceReady(spec MultitrackSpec, feed service.Feed) error {
+	mt.displayResourceTrackerMessageF("svc", spec, "become READY")
+	mt.emitResourceEvent("svc", spec, Event{Type: ResourceReadyEvent})
+
+	return mt.handleResourceReadyCondition(mt.TrackingServices, spec)
+}
+
+func (mt *multitracker) serviceFailed(spec MultitrackSpec, feed service.Feed, reason string) error {
+	mt.displayResourceErrorF("svc", spec, "%s", reason)
+	mt.emitResourceEvent("svc", spec, Event{Type: ResourceFailedEvent, Message: reason})
+
+	return mt.handleResourceFailure(mt.TrackingServices, "svc", spec, reason)
+}
+
+func (mt *multitracker) serviceEventMsg(spec MultitrackSpec, feed service.Feed, msg string) error {
+	mt.displayResourceEventF("svc", spec, "%s", msg)
+	return nil
+}
@@ -0,0 +1,216 @@
+package multitrack
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/flant/kubedog/pkg/tracker/generic"
+)
+
+// specFileSpecs is the format of the specs file, which is the same as MultitrackSpecs
+// with regular expressions and durations written as strings.
+type specFileSpecs struct {
+	Deployments            []specFileSpec `yaml:"Deployments"`
+	StatefulSets           []specFileSpec `yaml:"StatefulSets"`
+	DaemonSets             []specFileSpec `yaml:"DaemonSets"`
+	Jobs                   []specFileSpec `yaml:"Jobs"`
+	CronJobs               []specFileSpec `yaml:"CronJobs"`
+	Pods                   []specFileSpec `yaml:"Pods"`
+	Services               []specFileSpec `yaml:"Services"`
+	Ingresses              []specFileSpec `yaml:"Ingresses"`
+	PersistentVolumeClaims []specFileSpec `yaml:"PersistentVolumeClaims"`
+	Rollouts               []specFileSpec `yaml:"Rollouts"`
+	Generic                []specFileSpec `yaml:"Generic"`
+	Selectors              []specFileSpec `yaml:"Selectors"`
+}
+
+type specFileSpec struct {
+	ResourceName  string `yaml:"ResourceName"`
+	Namespace     string `yaml:"Namespace"`
+	LabelSelector string `yaml:"LabelSelector"`
+
+	TrackTerminationMode    TrackTerminationMode `yaml:"TrackTerminationMode"`
+	FailMode                FailMode             `yaml:"FailMode"`
+	AllowFailuresCount      *int                 `yaml:"AllowFailuresCount"`
+	FailureThresholdSeconds *specFileDuration    `yaml:"FailureThresholdSeconds"`
+	ReadyStabilitySeconds   *specFileDuration    `yaml:"ReadyStabilitySeconds"`
+	TimeoutSeconds          *specFileDuration    `yaml:"TimeoutSeconds"`
+
+	LogRegex                string            `yaml:"LogRegex"`
+	LogRegexByContainerName map[string]string `yaml:"LogRegexByContainerName"`
+
+	SkipLogs                  bool            `yaml:"SkipLogs"`
+	SkipLogsForContainers     []string        `yaml:"SkipLogsForContainers"`
+	ShowLogsOnlyForContainers []string        `yaml:"ShowLogsOnlyForContainers"`
+	ShowLogsUntil             DeployCondition `yaml:"ShowLogsUntil"`
+
+	ShowServiceMessages bool `yaml:"ShowServiceMessages"`
+
+	DependsOn                      []string `yaml:"DependsOn"`
+	SkipLogsUntilDependenciesReady bool     `yaml:"SkipLogsUntilDependenciesReady"`
+
+	WaitForNextRun bool `yaml:"WaitForNextRun"`
+
+	MinReadyEndpoints *int `yaml:"MinReadyEndpoints"`
+	UseEndpointSlices bool `yaml:"UseEndpointSlices"`
+
+	Kind             string                  `yaml:"Kind"`
+	APIVersion       string                  `yaml:"APIVersion"`
+	ReadyConditions  []specFileConditionRule `yaml:"ReadyConditions"`
+	FailedConditions []specFileConditionRule `yaml:"FailedConditions"`
+	ReadyJSONPaths   []string                `yaml:"ReadyJSONPaths"`
+	FailedJSONPaths  []string                `yaml:"FailedJSONPaths"`
+}
+
+type specFileConditionRule struct {
+	Type    string   `yaml:"Type"`
+	Status  string   `yaml:"Status"`
+	Reasons []string `yaml:"Reasons"`
+}
+
+// specFileDuration is the number of seconds or the duration string such as "90s" or "2m30s"
+type specFileDuration int
+
+func (d *specFileDuration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return fmt.Errorf("bad duration %q: should not be negative", value)
+		}
+		*d = specFileDuration(seconds)
+		return nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("bad duration %q: number of seconds or duration such as 90s or 2m30s expected", value)
+	}
+	if duration < 0 {
+		return fmt.Errorf("bad duration %q: should not be negative", value)
+	}
+	if duration%time.Second != 0 {
+		return fmt.Errorf("bad duration %q: should be a whole number of seconds", value)
+	}
+
+	*d = specFileDuration(duration / time.Second)
+
+	return nil
+}
+
+// ParseSpecsFile parses specs in yaml or json format. Parsing is strict: unknown and duplicated keys are errors.
+// LogRegex and LogRegexByContainerName are regular expressions strings,
+// FailureThresholdSeconds, ReadyStabilitySeconds and TimeoutSeconds are numbers of seconds or durations such as "90s".
+func ParseSpecsFile(data []byte) (MultitrackSpecs, error) {
+	fileSpecs := specFileSpecs{}
+	if err := yaml.UnmarshalStrict(data, &fileSpecs); err != nil {
+		return MultitrackSpecs{}, fmt.Errorf("unable to parse specs: %s", strings.TrimPrefix(err.Error(), "yaml: "))
+	}
+
+	specs := MultitrackSpecs{}
+
+	for _, list := range []struct {
+		Name      string
+		FileSpecs []specFileSpec
+		Specs     *[]MultitrackSpec
+	}{
+		{"Deployments", fileSpecs.Deployments, &specs.Deployments},
+		{"StatefulSets", fileSpecs.StatefulSets, &specs.StatefulSets},
+		{"DaemonSets", fileSpecs.DaemonSets, &specs.DaemonSets},
+		{"Jobs", fileSpecs.Jobs, &specs.Jobs},
+		{"CronJobs", fileSpecs.CronJobs, &specs.CronJobs},
+		{"Pods", fileSpecs.Pods, &specs.Pods},
+		{"Services", fileSpecs.Services, &specs.Services},
+		{"Ingresses", fileSpecs.Ingresses, &specs.Ingresses},
+		{"PersistentVolumeClaims", fileSpecs.PersistentVolumeClaims, &specs.PersistentVolumeClaims},
+		{"Rollouts", fileSpecs.Rollouts, &specs.Rollouts},
+		{"Generic", fileSpecs.Generic, &specs.Generic},
+		{"Selectors", fileSpecs.Selectors, &specs.Selectors},
+	} {
+		for i, fileSpec := range list.FileSpecs {
+			spec, err := fileSpec.multitrackSpec()
+			if err != nil {
+				return MultitrackSpecs{}, fmt.Errorf("unable to parse specs: %s[%d]: %s", list.Name, i, err)
+			}
+			*list.Specs = append(*list.Specs, spec)
+		}
+	}
+
+	return specs, nil
+}
+
+func (s specFileSpec) multitrackSpec() (MultitrackSpec, error) {
+	spec := MultitrackSpec{
+		ResourceName:                   s.ResourceName,
+		Namespace:                      s.Namespace,
+		LabelSelector:                  s.LabelSelector,
+		TrackTerminationMode:           s.TrackTerminationMode,
+		FailMode:                       s.FailMode,
+		AllowFailuresCount:             s.AllowFailuresCount,
+		FailureThresholdSeconds:        s.FailureThresholdSeconds.seconds(),
+		ReadyStabilitySeconds:          s.ReadyStabilitySeconds.seconds(),
+		TimeoutSeconds:                 s.TimeoutSeconds.seconds(),
+		SkipLogs:                       s.SkipLogs,
+		SkipLogsForContainers:          s.SkipLogsForContainers,
+		ShowLogsOnlyForContainers:      s.ShowLogsOnlyForContainers,
+		ShowLogsUntil:                  s.ShowLogsUntil,
+		ShowServiceMessages:            s.ShowServiceMessages,
+		DependsOn:                      s.DependsOn,
+		SkipLogsUntilDependenciesReady: s.SkipLogsUntilDependenciesReady,
+		WaitForNextRun:                 s.WaitForNextRun,
+		MinReadyEndpoints:              s.MinReadyEndpoints,
+		UseEndpointSlices:              s.UseEndpointSlices,
+		Kind:                           s.Kind,
+		APIVersion:                     s.APIVersion,
+		ReadyJSONPaths:                 s.ReadyJSONPaths,
+		FailedJSONPaths:                s.FailedJSONPaths,
+	}
+
+	if err := validateSpecModes(spec); err != nil {
+		return MultitrackSpec{}, err
+	}
+
+	if s.LogRegex != "" {
+		logRegex, err := regexp.Compile(s.LogRegex)
+		if err != nil {
+			return MultitrackSpec{}, fmt.Errorf("bad LogRegex %q: %s", s.LogRegex, err)
+		}
+		spec.LogRegex = logRegex
+	}
+
+	if len(s.LogRegexByContainerName) > 0 {
+		spec.LogRegexByContainerName = make(map[string]*regexp.Regexp)
+		for containerName, value := range s.LogRegexByContainerName {
+			logRegex, err := regexp.Compile(value)
+			if err != nil {
+				return MultitrackSpec{}, fmt.Errorf("bad LogRegexByContainerName %q regex %q: %s", containerName, value, err)
+			}
+			spec.LogRegexByContainerName[containerName] = logRegex
+		}
+	}
+
+	for _, rule := range s.ReadyConditions {
+		spec.ReadyConditions = append(spec.ReadyConditions, generic.ConditionRule{Type: rule.Type, Status: rule.Status, Reasons: rule.Reasons})
+	}
+	for _, rule := range s.FailedConditions {
+		spec.FailedConditions = append(spec.FailedConditions, generic.ConditionRule{Type: rule.Type, Status: rule.Status, Reasons: rule.Reasons})
+	}
+
+	return spec, nil
+}
+
+func (d *specFileDuration) seconds() *int {
+	if d == nil {
+		return nil
+	}
+	seconds := int(*d)
+	return &seconds
+}
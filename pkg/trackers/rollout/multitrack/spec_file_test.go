@@ -0,0 +1,223 @@
+package multitrack
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/flant/kubedog/pkg/tracker/generic"
+)
+
+func TestParseSpecsFile(t *testing.T) {
+	data := `
+Deployments:
+- ResourceName: app
+  Namespace: myns
+  FailMode: HopeUntilEndOfDeployProcess
+  AllowFailuresCount: 2
+  FailureThresholdSeconds: 30
+  TimeoutSeconds: 2m30s
+  LogRegex: "(ERROR|WARN)"
+  LogRegexByContainerName:
+    sidecar: "^level=error"
+  SkipLogsForContainers: [init]
+  ShowLogsUntil: EndOfDeploy
+  DependsOn: [job/migrate]
+Jobs:
+- ResourceName: migrate
+  Namespace: myns
+  TrackTerminationMode: NonBlocking
+Generic:
+- ResourceName: mycert
+  Namespace: myns
+  Kind: Certificate
+  APIVersion: cert-manager.io/v1
+  ReadyConditions:
+  - Type: Ready
+    Status: "True"
+  FailedConditions:
+  - Type: Issuing
+    Status: "False"
+    Reasons: [Failed, Denied]
+  ReadyJSONPaths:
+  - .status.notAfter != null
+`
+
+	specs, err := ParseSpecsFile([]byte(data))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(specs.Deployments) != 1 || len(specs.Jobs) != 1 || len(specs.Generic) != 1 {
+		t.Fatalf("unexpected specs %+v", specs)
+	}
+
+	deploySpec := specs.Deployments[0]
+	if deploySpec.ResourceName != "app" || deploySpec.Namespace != "myns" {
+		t.Errorf("unexpected resource %s/%s", deploySpec.Namespace, deploySpec.ResourceName)
+	}
+	if deploySpec.FailMode != HopeUntilEndOfDeployProcess {
+		t.Errorf("expected FailMode %q, got %q", HopeUntilEndOfDeployProcess, deploySpec.FailMode)
+	}
+	if deploySpec.AllowFailuresCount == nil || *deploySpec.AllowFailuresCount != 2 {
+		t.Errorf("expected AllowFailuresCount 2, got %v", deploySpec.AllowFailuresCount)
+	}
+	if deploySpec.FailureThresholdSeconds == nil || *deploySpec.FailureThresholdSeconds != 30 {
+		t.Errorf("expected FailureThresholdSeconds 30, got %v", deploySpec.FailureThresholdSeconds)
+	}
+	if deploySpec.TimeoutSeconds == nil || *deploySpec.TimeoutSeconds != 150 {
+		t.Errorf("expected TimeoutSeconds 150, got %v", deploySpec.TimeoutSeconds)
+	}
+	if deploySpec.ReadyStabilitySeconds != nil {
+		t.Errorf("expected no ReadyStabilitySeconds, got %v", *deploySpec.ReadyStabilitySeconds)
+	}
+	if deploySpec.LogRegex == nil || !deploySpec.LogRegex.MatchString("WARN: x") {
+		t.Errorf("unexpected LogRegex %v", deploySpec.LogRegex)
+	}
+	if logRegex := deploySpec.LogRegexByContainerName["sidecar"]; logRegex == nil || !logRegex.MatchString("level=error msg=x") {
+		t.Errorf("unexpected LogRegexByContainerName %v", deploySpec.LogRegexByContainerName)
+	}
+	if want := []string{"init"}; !reflect.DeepEqual(deploySpec.SkipLogsForContainers, want) {
+		t.Errorf("expected SkipLogsForContainers %v, got %v", want, deploySpec.SkipLogsForContainers)
+	}
+	if deploySpec.ShowLogsUntil != EndOfDeploy {
+		t.Errorf("expected ShowLogsUntil %q, got %q", EndOfDeploy, deploySpec.ShowLogsUntil)
+	}
+	if want := []string{"job/migrate"}; !reflect.DeepEqual(deploySpec.DependsOn, want) {
+		t.Errorf("expected DependsOn %v, got %v", want, deploySpec.DependsOn)
+	}
+
+	if specs.Jobs[0].TrackTerminationMode != NonBlocking {
+		t.Errorf("expected TrackTerminationMode %q, got %q", NonBlocking, specs.Jobs[0].TrackTerminationMode)
+	}
+
+	genericSpec := specs.Generic[0]
+	if genericSpec.Kind != "Certificate" || genericSpec.APIVersion != "cert-manager.io/v1" {
+		t.Errorf("unexpected generic resource %s %s", genericSpec.APIVersion, genericSpec.Kind)
+	}
+	if want := []generic.ConditionRule{{Type: "Ready", Status: "True"}}; !reflect.DeepEqual(genericSpec.ReadyConditions, want) {
+		t.Errorf("expected ReadyConditions %+v, got %+v", want, genericSpec.ReadyConditions)
+	}
+	if want := []generic.ConditionRule{{Type: "Issuing", Status: "False", Reasons: []string{"Failed", "Denied"}}}; !reflect.DeepEqual(genericSpec.FailedConditions, want) {
+		t.Errorf("expected FailedConditions %+v, got %+v", want, genericSpec.FailedConditions)
+	}
+	if want := []string{".status.notAfter != null"}; !reflect.DeepEqual(genericSpec.ReadyJSONPaths, want) {
+		t.Errorf("expected ReadyJSONPaths %v, got %v", want, genericSpec.ReadyJSONPaths)
+	}
+}
+
+func TestParseSpecsFileJSON(t *testing.T) {
+	specs, err := ParseSpecsFile([]byte(`{"StatefulSets": [{"ResourceName": "db", "ReadyStabilitySeconds": 10}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(specs.StatefulSets) != 1 || specs.StatefulSets[0].ResourceName != "db" {
+		t.Fatalf("unexpected specs %+v", specs)
+	}
+	if seconds := specs.StatefulSets[0].ReadyStabilitySeconds; seconds == nil || *seconds != 10 {
+		t.Fatalf("expected ReadyStabilitySeconds 10, got %v", seconds)
+	}
+}
+
+func TestParseSpecsFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name:    "unknown kind",
+			data:    "Secrets:\n- ResourceName: app\n",
+			wantErr: "field Secrets not found",
+		},
+		{
+			name:    "unknown field",
+			data:    "Deployments:\n- ResourceName: app\n  Timeout: 10\n",
+			wantErr: "field Timeout not found",
+		},
+		{
+			name:    "duplicated field",
+			data:    "Deployments:\n- ResourceName: app\n  ResourceName: other\n",
+			wantErr: "already set",
+		},
+		{
+			name:    "bad yaml",
+			data:    "Deployments: [",
+			wantErr: "unable to parse specs",
+		},
+		{
+			name:    "bad fail mode",
+			data:    "Deployments:\n- ResourceName: app\n- ResourceName: other\n  FailMode: Never\n",
+			wantErr: `unable to parse specs: Deployments[1]: bad FailMode "Never"`,
+		},
+		{
+			name:    "bad track termination mode",
+			data:    "Jobs:\n- ResourceName: migrate\n  TrackTerminationMode: Forever\n",
+			wantErr: `Jobs[0]: bad TrackTerminationMode "Forever"`,
+		},
+		{
+			name:    "bad show logs until",
+			data:    "Pods:\n- ResourceName: debug\n  ShowLogsUntil: Never\n",
+			wantErr: `Pods[0]: bad ShowLogsUntil "Never"`,
+		},
+		{
+			name:    "bad log regex",
+			data:    "Deployments:\n- ResourceName: app\n  LogRegex: \"(ERROR\"\n",
+			wantErr: `Deployments[0]: bad LogRegex "(ERROR"`,
+		},
+		{
+			name:    "bad log regex by container name",
+			data:    "Deployments:\n- ResourceName: app\n  LogRegexByContainerName:\n    sidecar: \"[a-\"\n",
+			wantErr: `Deployments[0]: bad LogRegexByContainerName "sidecar" regex "[a-"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSpecsFile([]byte(tt.data))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSpecFileDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr string
+	}{
+		{value: "0", want: 0},
+		{value: "90", want: 90},
+		{value: "90s", want: 90},
+		{value: "2m30s", want: 150},
+		{value: "1h", want: 3600},
+		{value: "-1", wantErr: "should not be negative"},
+		{value: "-10s", wantErr: "should not be negative"},
+		{value: "1.5", wantErr: "number of seconds or duration such as 90s or 2m30s expected"},
+		{value: "1500ms", wantErr: "should be a whole number of seconds"},
+		{value: "soon", wantErr: "number of seconds or duration such as 90s or 2m30s expected"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			specs, err := ParseSpecsFile([]byte("Deployments:\n- ResourceName: app\n  TimeoutSeconds: " + tt.value + "\n"))
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if seconds := specs.Deployments[0].TimeoutSeconds; seconds == nil || *seconds != tt.want {
+				t.Fatalf("expected %d seconds, got %v", tt.want, seconds)
+			}
+		})
+	}
+}
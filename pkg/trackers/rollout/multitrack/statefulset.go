@@ -15,7 +15,7 @@ func (mt *multitracker) TrackStatefulSet(kube kubernetes.Interface, spec Multitr
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.StatefulSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.StatefulSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.statefulsetAdded(spec, feed, isReady)
 	})
@@ -23,7 +23,7 @@ func (mt *multitracker) TrackStatefulSet(kube kubernetes.Interface, spec Multitr
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.StatefulSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.StatefulSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.statefulsetReady(spec, feed)
 	})
@@ -31,7 +31,7 @@ func (mt *multitracker) TrackStatefulSet(kube kubernetes.Interface, spec Multitr
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.StatefulSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.StatefulSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.statefulsetFailed(spec, feed, reason)
 	})
@@ -39,7 +39,7 @@ func (mt *multitracker) TrackStatefulSet(kube kubernetes.Interface, spec Multitr
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.StatefulSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.StatefulSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.statefulsetEventMsg(spec, feed, msg)
 	})
@@ -47,7 +47,7 @@ func (mt *multitracker) TrackStatefulSet(kube kubernetes.Interface, spec Multitr
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.StatefulSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.StatefulSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.statefulsetAddedReplicaSet(spec, feed, rs)
 	})
@@ -55,7 +55,7 @@ func (mt *multitracker) TrackStatefulSet(kube kubernetes.Interface, spec Multitr
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.StatefulSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.StatefulSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.statefulsetAddedPod(spec, feed, pod)
 	})
@@ -63,7 +63,7 @@ func (mt *multitracker) TrackStatefulSet(kube kubernetes.Interface, spec Multitr
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.StatefulSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.StatefulSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.statefulsetPodError(spec, feed, podError)
 	})
@@ -71,7 +71,7 @@ func (mt *multitracker) TrackStatefulSet(kube kubernetes.Interface, spec Multitr
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.StatefulSetsStatuses[spec.ResourceName] = feed.GetStatus()
+		mt.StatefulSetsStatuses[resourceKey(spec)] = feed.GetStatus()
 
 		return mt.statefulsetPodLogChunk(spec, feed, chunk)
 	})
@@ -79,34 +79,44 @@ func (mt *multitracker) TrackStatefulSet(kube kubernetes.Interface, spec Multitr
 		mt.mux.Lock()
 		defer mt.mux.Unlock()
 
-		mt.StatefulSetsStatuses[spec.ResourceName] = status
+		mt.StatefulSetsStatuses[resourceKey(spec)] = status
 
-		return nil
+		if !status.IsFailed && !hasPodsErrors(status.Pods, status.NewPodsNames) {
+			mt.resetResourceFailure(mt.TrackingStatefulSets, spec)
+		}
+
+		return mt.handleResourceReadyStability(mt.TrackingStatefulSets, "sts", spec, status.IsReady, podsRestartsCount(status.Pods, status.NewPodsNames))
 	})
 
 	return feed.Track(spec.ResourceName, spec.Namespace, kube, opts.Options)
 }
 
 func (mt *multitracker) statefulsetAdded(spec MultitrackSpec, feed statefulset.Feed, isReady bool) error {
+	mt.handleResourceAdded(mt.TrackingStatefulSets, spec)
+
 	if isReady {
 		mt.displayResourceTrackerMessageF("sts", spec, "appears to be READY")
+		mt.emitResourceEvent("sts", spec, Event{Type: ResourceReadyEvent})
 
 		return mt.handleResourceReadyCondition(mt.TrackingStatefulSets, spec)
 	}
 
 	mt.displayResourceTrackerMessageF("sts", spec, "added")
+	mt.emitResourceEvent("sts", spec, Event{Type: ResourceAddedEvent})
 
 	return nil
 }
 
 func (mt *multitracker) statefulsetReady(spec MultitrackSpec, feed statefulset.Feed) error {
 	mt.displayResourceTrackerMessageF("sts", spec, "become READY")
+	mt.emitResourceEvent("sts", spec, Event{Type: ResourceReadyEvent})
 
 	return mt.handleResourceReadyCondition(mt.TrackingStatefulSets, spec)
 }
 
 func (mt *multitracker) statefulsetFailed(spec MultitrackSpec, feed statefulset.Feed, reason string) error {
 	mt.displayResourceErrorF("sts", spec, "%s", reason)
+	mt.emitResourceEvent("sts", spec, Event{Type: ResourceFailedEvent, Message: reason})
 	return mt.handleResourceFailure(mt.TrackingStatefulSets, "sts", spec, reason)
 }
 
@@ -122,25 +132,26 @@ func (mt *multitracker) statefulsetAddedReplicaSet(spec MultitrackSpec, feed sta
 
 func (mt *multitracker) statefulsetAddedPod(spec MultitrackSpec, feed statefulset.Feed, pod replicaset.ReplicaSetPod) error {
 	mt.displayResourceTrackerMessageF("sts", spec, "po/%s added", pod.Name)
+	mt.emitResourceEvent("sts", spec, Event{Type: PodAddedEvent, Pod: pod.Name})
 	return nil
 }
 
 func (mt *multitracker) statefulsetPodError(spec MultitrackSpec, feed statefulset.Feed, podError replicaset.ReplicaSetPodError) error {
 	reason := fmt.Sprintf("po/%s container/%s: %s", podError.PodName, podError.ContainerName, podError.Message)
+	reason = withContainerFailureHint(reason, mt.StatefulSetsStatuses[resourceKey(spec)].Pods, podError.PodName, podError.ContainerName)
 
 	mt.displayResourceErrorF("sts", spec, "%s", reason)
+	mt.emitResourceEvent("sts", spec, Event{Type: ContainerErrorEvent, Pod: podError.PodName, Container: podError.ContainerName, Message: podError.Message})
 
 	return mt.handleResourceFailure(mt.TrackingStatefulSets, "sts", spec, reason)
 }
 
 func (mt *multitracker) statefulsetPodLogChunk(spec MultitrackSpec, feed statefulset.Feed, chunk *replicaset.ReplicaSetPodLogChunk) error {
-	status := mt.StatefulSetsStatuses[spec.ResourceName]
-	if podStatus, hasKey := status.Pods[chunk.PodName]; hasKey {
-		if podStatus.IsReady {
-			return nil
-		}
+	status := mt.StatefulSetsStatuses[resourceKey(spec)]
+	if !isPodLogsVisible(spec, status.Pods, chunk.PodName) {
+		return nil
 	}
 
-	mt.displayResourceLogChunk("sts", spec, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk), chunk.ContainerLogChunk)
+	mt.displayResourceLogChunk("sts", spec, chunk.PodName, podContainerLogChunkHeader(chunk.PodName, chunk.ContainerLogChunk), chunk.ContainerLogChunk)
 	return nil
 }
@@ -1,5 +1,7 @@
 package multitrack
 
+import "github.com/flant/kubedog/pkg/tracker/pod"
+
 func appendElemIfNotExist(arr []string, newElem string) []string {
 	for _, elem := range arr {
 		if elem == newElem {
@@ -20,3 +22,37 @@ func deleteElemFromArray(arr []string, elemToDelete string) []string {
 
 	return newArr
 }
+
+func podsNames(pods map[string]pod.PodStatus) []string {
+	names := []string{}
+	for name := range pods {
+		names = append(names, name)
+	}
+	return names
+}
+
+// hasPodsErrors checks whether any of the specified pods is failed or has containers errors
+func hasPodsErrors(pods map[string]pod.PodStatus, names []string) bool {
+	for _, name := range names {
+		podStatus, hasKey := pods[name]
+		if !hasKey {
+			continue
+		}
+
+		if podStatus.IsFailed || len(podStatus.ContainersErrors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// podsRestartsCount sums containers restarts of the specified pods
+func podsRestartsCount(pods map[string]pod.PodStatus, names []string) int32 {
+	var count int32
+	for _, name := range names {
+		if podStatus, hasKey := pods[name]; hasKey {
+			count += podStatus.Restarts
+		}
+	}
+	return count
+}
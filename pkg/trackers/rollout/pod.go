@@ -39,8 +39,8 @@ func TrackPodTillReady(name, namespace string, kube kubernetes.Interface, opts t
 		return tracker.ResourceErrorf("po/%s %s failed: %s", name, containerError.ContainerName, containerError.Message)
 	})
 	feed.OnContainerLogChunk(func(chunk *pod.ContainerLogChunk) error {
-		header := fmt.Sprintf("po/%s %s", name, chunk.ContainerName)
-		display.OutputLogLines(header, chunk.LogLines)
+		header := fmt.Sprintf("po/%s %s", name, chunk.Label())
+		display.OutputLogLines(header, chunk.DisplayLogLines())
 		return nil
 	})
 
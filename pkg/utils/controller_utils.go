@@ -8,6 +8,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -80,6 +82,19 @@ func ControllerAccessor(controller interface{}) ControllerMetadata {
 			Spec:       c.Spec.Template.Spec,
 		}
 		w.labelSelector = c.Spec.Selector
+	case *unstructured.Unstructured:
+		// Custom controllers (such as Argo Rollouts) with Deployment-like spec.selector and spec.template
+		if template, found, err := unstructured.NestedMap(c.Object, "spec", "template"); err == nil && found {
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(template, &w.replicaSetTemplate); err != nil && debug() {
+				fmt.Printf("ControllerAccessor for %s template error: %v", c.GetName(), err)
+			}
+		}
+		if selector, found, err := unstructured.NestedMap(c.Object, "spec", "selector"); err == nil && found {
+			w.labelSelector = &metav1.LabelSelector{}
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(selector, w.labelSelector); err != nil && debug() {
+				fmt.Printf("ControllerAccessor for %s selector error: %v", c.GetName(), err)
+			}
+		}
 	}
 	return w
 }